@@ -0,0 +1,188 @@
+package mappath
+
+import (
+	"fmt"
+	"iter"
+	"math"
+)
+
+// Count returns number of values matched by key. The key may be a pattern with the same syntax as Match accepts.
+// If a key without wildcards resolves to a slice, its elements are counted.
+//
+// Other aggregation helpers match values the same way. Invalid keys match nothing,
+// helpers returning an error return InvalidPathError for them.
+func Count(p any, key string) int {
+	n := 0
+	for range aggregated(p, key) {
+		n++
+	}
+	return n
+}
+
+// Sum returns sum of numbers matched by key. Nil values are skipped, other non-numeric values cause an error.
+func Sum(p any, key string) (float64, error) {
+	nums, err := numbers(p, key)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, n := range nums {
+		sum += n
+	}
+	return sum, nil
+}
+
+// Min returns the smallest number matched by key.
+func Min(p any, key string) (float64, error) {
+	nums, err := nonEmptyNumbers(p, key)
+	if err != nil {
+		return 0, err
+	}
+
+	m := math.Inf(1)
+	for _, n := range nums {
+		m = min(m, n)
+	}
+	return m, nil
+}
+
+// Max returns the largest number matched by key.
+func Max(p any, key string) (float64, error) {
+	nums, err := nonEmptyNumbers(p, key)
+	if err != nil {
+		return 0, err
+	}
+
+	m := math.Inf(-1)
+	for _, n := range nums {
+		m = max(m, n)
+	}
+	return m, nil
+}
+
+// Avg returns arithmetic mean of numbers matched by key.
+func Avg(p any, key string) (float64, error) {
+	nums, err := nonEmptyNumbers(p, key)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, n := range nums {
+		sum += n
+	}
+	return sum / float64(len(nums)), nil
+}
+
+// Distinct returns unique values matched by key in order of their first appearance.
+// Numbers are compared by value, other values - deeply.
+func Distinct(p any, key string) []any {
+	var result []any
+	for _, val := range aggregated(p, key) {
+		found := false
+		for _, r := range result {
+			if equal(r, val) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, val)
+		}
+	}
+	return result
+}
+
+// GroupBy groups values matched by key by string representation of their values by `by` key.
+// Values without `by` key are grouped under an empty string.
+//
+//	GroupBy(order, "items", "category") // {"books": [...], "food": [...]}
+func GroupBy(p any, key, by string) map[string][]any {
+	groups := make(map[string][]any)
+	for _, val := range aggregated(p, key) {
+		g, _ := Get(val, by)
+		k := toString(g)
+		groups[k] = append(groups[k], val)
+	}
+	return groups
+}
+
+// aggregated yields values matched by key.
+func aggregated(p any, key string) iter.Seq2[string, any] {
+	if isPattern(key) {
+		return Match(p, key)
+	}
+
+	return func(yield func(string, any) bool) {
+		val, err := Get(p, key)
+		if err != nil {
+			return
+		}
+
+		if _, ok := lenOf(val); !ok {
+			yield(key, val)
+			return
+		}
+
+		for _, k := range childrenOf(val) {
+			elem, err := searchInNode(val, k)
+			if err != nil {
+				continue
+			}
+
+			if !yield(joinPath(key, k), elem) {
+				return
+			}
+		}
+	}
+}
+
+func numbers(p any, key string) ([]float64, error) {
+	if len(key) == 0 {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key length cannot be zero",
+		}
+	}
+
+	if key[0] == '.' && key != "." {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key cannot start from dot",
+		}
+	}
+
+	var nums []float64
+	for path, val := range aggregated(p, key) {
+		if val == nil {
+			continue
+		}
+
+		n, ok := toFloat(val)
+		if !ok {
+			return nil, &ConversionError{
+				Path:   path,
+				Reason: fmt.Sprintf("value %v of type %T is not a number", val, val),
+			}
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+func nonEmptyNumbers(p any, key string) ([]float64, error) {
+	nums, err := numbers(p, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nums) == 0 {
+		return nil, &NotFoundError{
+			Path:   key,
+			Reason: "no numbers matched",
+		}
+	}
+	return nums, nil
+}
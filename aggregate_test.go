@@ -0,0 +1,138 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+var testOrder = map[string]any{
+	"id": "order-1",
+	"items": []any{
+		map[string]any{"sku": "a", "price": 10.5, "qty": 2, "category": "books"},
+		map[string]any{"sku": "b", "price": 4, "qty": 1, "category": "food"},
+		map[string]any{"sku": "c", "price": nil, "category": "books"},
+		map[string]any{"sku": "d", "price": 25.5, "qty": 1},
+	},
+	"tags":   []any{"gift", "express", "gift"},
+	"labels": []any{"x", 1, "y"},
+}
+
+func TestAggregations(t *testing.T) {
+	tests := map[string]struct {
+		fn     func(p any, key string) (float64, error)
+		key    string
+		result float64
+		err    error
+	}{
+		"sum by pattern": {
+			fn:     mappath.Sum,
+			key:    "items.*.price",
+			result: 40,
+		},
+		"sum of nothing": {
+			fn:     mappath.Sum,
+			key:    "items.*.discount",
+			result: 0,
+		},
+		"min": {
+			fn:     mappath.Min,
+			key:    "items.*.price",
+			result: 4,
+		},
+		"max": {
+			fn:     mappath.Max,
+			key:    "items.*.qty",
+			result: 2,
+		},
+		"avg skips nils": {
+			fn:     mappath.Avg,
+			key:    "items.*.price",
+			result: 40.0 / 3,
+		},
+		"avg of nothing, not found": {
+			fn:  mappath.Avg,
+			key: "items.*.discount",
+			err: &mappath.NotFoundError{},
+		},
+		"empty key, invalid path": {
+			fn:  mappath.Sum,
+			key: "",
+			err: &mappath.InvalidPathError{},
+		},
+		"key starting from dot, invalid path": {
+			fn:  mappath.Min,
+			key: ".items",
+			err: &mappath.InvalidPathError{},
+		},
+		"not a number, conversion error": {
+			fn:  mappath.Sum,
+			key: "items.*.sku",
+			err: &mappath.ConversionError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := test.fn(testOrder, test.key)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if val != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	for key, want := range map[string]int{
+		"items":         4,
+		"items.*.price": 4,
+		"items.*.qty":   3,
+		"id":            1,
+		"foo":           0,
+	} {
+		if got := mappath.Count(testOrder, key); got != want {
+			t.Errorf("unexpected result for %v - want: %v, got: %v", key, want, got)
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	got := mappath.Distinct(testOrder, "tags")
+	want := []any{"gift", "express"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+
+	got = mappath.Distinct(testOrder, "items.*.qty")
+	want = []any{2, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	items := testOrder["items"].([]any)
+
+	got := mappath.GroupBy(testOrder, "items", "category")
+	want := map[string][]any{
+		"books": {items[0], items[2]},
+		"food":  {items[1]},
+		"":      {items[3]},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+}
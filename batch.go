@@ -0,0 +1,292 @@
+package mappath
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+)
+
+type OpKind int
+
+const (
+	// OpPut puts a value by key.
+	OpPut OpKind = iota
+	// OpDelete deletes a value by key.
+	OpDelete
+	// OpMove deletes a value by from key and puts it by key.
+	OpMove
+	// OpCopy puts a clone of a value by from key by key.
+	OpCopy
+)
+
+// Op is a single operation of a batch.
+type Op struct {
+	Kind OpKind
+	// Key is a target key of the operation.
+	Key string
+	// From is a source key for OpMove and OpCopy.
+	From string
+	// Value is a value for OpPut.
+	Value any
+}
+
+// PutOp returns an operation, that puts the value by key.
+func PutOp(key string, val any) Op {
+	return Op{Kind: OpPut, Key: key, Value: val}
+}
+
+// DeleteOp returns an operation, that deletes a value by key.
+func DeleteOp(key string) Op {
+	return Op{Kind: OpDelete, Key: key}
+}
+
+// MoveOp returns an operation, that moves a value from one key to another.
+func MoveOp(from, to string) Op {
+	return Op{Kind: OpMove, Key: to, From: from}
+}
+
+// CopyOp returns an operation, that puts a clone of a value by one key to another.
+func CopyOp(from, to string) Op {
+	return Op{Kind: OpCopy, Key: to, From: from}
+}
+
+// Apply performs operations in order and returns the updated object, like a sequence of Put and Delete calls does.
+//
+// Maps and Nodes passed through by operations are remembered by their paths, so operations under a shared prefix,
+// like `metadata.user.login` and `metadata.user.email`, do not traverse the data from the root each time.
+//
+// Operations are not transactional: if one fails, the error is returned, but data may already be modified by previous ones.
+func Apply(p any, ops ...Op) (any, error) {
+	return DefaultLimits.Apply(p, ops...)
+}
+
+// Apply is the same as package Apply, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Apply(p any, ops ...Op) (any, error) {
+	b := &batch{
+		root:   p,
+		nodes:  make(map[string]any),
+		limits: l,
+	}
+
+	for _, op := range ops {
+		var err error
+		switch op.Kind {
+		case OpPut:
+			err = b.put(op.Key, op.Value)
+		case OpDelete:
+			err = b.delete(op.Key)
+		case OpMove:
+			var val any
+			if val, err = b.get(op.From); err != nil {
+				break
+			}
+
+			if err = b.delete(op.From); err != nil {
+				break
+			}
+			err = b.put(op.Key, val)
+		case OpCopy:
+			var val any
+			if val, err = b.get(op.From); err != nil {
+				break
+			}
+			err = b.put(op.Key, Clone(val))
+		default:
+			err = &InvalidPathError{
+				Path:   op.Key,
+				Reason: "unknown operation kind: " + strconv.Itoa(int(op.Kind)),
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.root, nil
+}
+
+// batch holds data and remembered nodes, that are updated in place, by their paths.
+type batch struct {
+	root   any
+	nodes  map[string]any
+	limits Limits
+}
+
+func (b *batch) get(key string) (any, error) {
+	if node, rest, ok := b.lookup(key); ok {
+		return Get(node, rest)
+	}
+	return Get(b.root, key)
+}
+
+func (b *batch) put(key string, val any) error {
+	if !isValidKey(key) {
+		root, err := b.limits.Put(b.root, key, val)
+		if err != nil {
+			return err
+		}
+
+		clear(b.nodes)
+		b.root = root
+		return nil
+	}
+
+	if err := b.limits.checkKey(key); err != nil {
+		return err
+	}
+
+	// number of nodes is checked for the whole data, so traversal starts from the root
+	b.forget(key)
+	if node, rest, ok := b.lookup(key); ok && b.limits.MaxNodes <= 0 {
+		if _, err := b.limits.putInPath(node, strings.Split(rest, "."), val); err != nil {
+			return err
+		}
+	} else {
+		root, err := b.limits.putInPath(b.root, strings.Split(key, "."), val)
+		if err != nil {
+			return err
+		}
+		b.root = root
+	}
+
+	b.remember(key)
+	return nil
+}
+
+func (b *batch) delete(key string) error {
+	if !isValidKey(key) {
+		root, err := b.limits.DeleteWithOpts(b.root, key, DeleteOpts{})
+		if err != nil {
+			return err
+		}
+
+		clear(b.nodes)
+		b.root = root
+		return nil
+	}
+
+	if err := b.limits.checkKey(key); err != nil {
+		return err
+	}
+
+	if node, rest, ok := b.lookup(key); ok {
+		if _, err := b.limits.deleteFromKey(node, rest, DeleteOpts{}); err != nil {
+			return err
+		}
+	} else {
+		root, err := b.limits.deleteFromKey(b.root, key, DeleteOpts{})
+		if err != nil {
+			return err
+		}
+		b.root = root
+	}
+
+	// deletion of a slice element shifts indexes of its siblings
+	parent, last := splitParent(key)
+	if _, err := strconv.Atoi(last); err == nil {
+		b.forgetChildren(parent)
+	} else {
+		b.forget(key)
+	}
+	return nil
+}
+
+// lookup returns the deepest remembered node, that is a parent of the key, and the rest of the key.
+func (b *batch) lookup(key string) (any, string, bool) {
+	for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+		if node, ok := b.nodes[key[:i]]; ok {
+			return node, key[i+1:], true
+		}
+	}
+	return nil, "", false
+}
+
+// remember saves maps and Nodes on the way to the key parent. Paths with negative or non-canonical indexes,
+// like `-1` or `01`, are not saved, because other paths may point to the same nodes.
+func (b *batch) remember(key string) {
+	parent, _ := splitParent(key)
+	if parent == "" {
+		return
+	}
+
+	if _, ok := b.nodes[parent]; ok {
+		return
+	}
+
+	node, path, rest := b.root, ".", parent
+	if n, r, ok := b.lookup(parent); ok {
+		node, path, rest = n, parent[:len(parent)-len(r)-1], r
+	}
+
+	for seg := range strings.SplitSeq(rest, ".") {
+		if !isCanonicalKey(seg) {
+			return
+		}
+
+		next, err := searchInNode(node, seg)
+		if err != nil {
+			return
+		}
+		node, path = next, joinPath(path, seg)
+
+		switch t := node.(type) {
+		case Node:
+			b.nodes[path] = t
+		case map[string]any:
+			if t != nil {
+				b.nodes[path] = t
+			}
+		}
+	}
+}
+
+// forget drops remembered nodes by the key and under it.
+// All nodes are dropped, if the key has indexes, that may refer to remembered nodes by other paths.
+func (b *batch) forget(key string) {
+	if !isCanonicalKey(key) {
+		clear(b.nodes)
+		return
+	}
+
+	maps.DeleteFunc(b.nodes, func(path string, _ any) bool {
+		return path == key || strings.HasPrefix(path, key+".")
+	})
+}
+
+// forgetChildren drops remembered nodes under the key, keeping the node by key itself.
+// The root key is empty.
+func (b *batch) forgetChildren(key string) {
+	if key == "" || !isCanonicalKey(key) {
+		clear(b.nodes)
+		return
+	}
+
+	maps.DeleteFunc(b.nodes, func(path string, _ any) bool {
+		return strings.HasPrefix(path, key+".")
+	})
+}
+
+// isValidKey reports whether the key is not a dot or an invalid key.
+func isValidKey(key string) bool {
+	return len(key) > 0 && key[0] != '.'
+}
+
+// splitParent splits the key into a parent path and the last segment. Parent of a top level key is empty.
+func splitParent(key string) (string, string) {
+	i := strings.LastIndexByte(key, '.')
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// isCanonicalKey reports whether all numeric segments of the key are non-negative integers without leading zeros or signs.
+func isCanonicalKey(key string) bool {
+	for seg := range strings.SplitSeq(key, ".") {
+		if i, err := strconv.Atoi(seg); err == nil && (i < 0 || strconv.Itoa(i) != seg) {
+			return false
+		}
+	}
+	return true
+}
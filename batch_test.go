@@ -0,0 +1,190 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestApply(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		ops    []mappath.Op
+		result any
+		err    error
+	}{
+		"puts under shared prefix": {
+			p: map[string]any{"message": "login"},
+			ops: []mappath.Op{
+				mappath.PutOp("metadata.user.login", "johndoe12"),
+				mappath.PutOp("metadata.user.email", "john@example.com"),
+				mappath.PutOp("metadata.host.name", "srv-1"),
+				mappath.PutOp("metadata.user.roles.1", "admin"),
+			},
+			result: map[string]any{
+				"message": "login",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"login": "johndoe12",
+						"email": "john@example.com",
+						"roles": []any{nil, "admin"},
+					},
+					"host": map[string]any{"name": "srv-1"},
+				},
+			},
+		},
+		"put into nil root": {
+			p: nil,
+			ops: []mappath.Op{
+				mappath.PutOp("a.b", 1),
+				mappath.PutOp("a.c", 2),
+			},
+			result: map[string]any{
+				"a": map[string]any{"b": 1, "c": 2},
+			},
+		},
+		"replaced prefix is not reused": {
+			p: map[string]any{},
+			ops: []mappath.Op{
+				mappath.PutOp("a.b.c", 1),
+				mappath.PutOp("a.b", map[string]any{"d": 2}),
+				mappath.PutOp("a.b.e", 3),
+			},
+			result: map[string]any{
+				"a": map[string]any{"b": map[string]any{"d": 2, "e": 3}},
+			},
+		},
+		"deleted slice element shifts remembered paths": {
+			p: map[string]any{
+				"items": []any{
+					map[string]any{"meta": map[string]any{}},
+					map[string]any{"meta": map[string]any{}},
+				},
+			},
+			ops: []mappath.Op{
+				mappath.PutOp("items.0.meta.a", 1),
+				mappath.DeleteOp("items.0"),
+				mappath.PutOp("items.0.meta.b", 2),
+			},
+			result: map[string]any{
+				"items": []any{
+					map[string]any{"meta": map[string]any{"b": 2}},
+				},
+			},
+		},
+		"negative indexes": {
+			p: map[string]any{
+				"items": []any{map[string]any{"id": 1}},
+			},
+			ops: []mappath.Op{
+				mappath.PutOp("items.-1.a", 1),
+				mappath.PutOp("items.1", map[string]any{"id": 2}),
+				mappath.PutOp("items.-1.a", 2),
+			},
+			result: map[string]any{
+				"items": []any{
+					map[string]any{"id": 1, "a": 1},
+					map[string]any{"id": 2, "a": 2},
+				},
+			},
+		},
+		"move and copy": {
+			p: map[string]any{
+				"usr": map[string]any{"login": "johndoe12", "groups": []any{"admin"}},
+			},
+			ops: []mappath.Op{
+				mappath.MoveOp("usr.login", "metadata.user.login"),
+				mappath.CopyOp("usr.groups", "metadata.user.roles"),
+				mappath.PutOp("metadata.user.roles.1", "manager"),
+				mappath.DeleteOp("usr"),
+			},
+			result: map[string]any{
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"login": "johndoe12",
+						"roles": []any{"admin", "manager"},
+					},
+				},
+			},
+		},
+		"root merge and delete": {
+			p: map[string]any{"a": 1},
+			ops: []mappath.Op{
+				mappath.PutOp("b.c", 2),
+				mappath.PutOp(".", map[string]any{"b": map[string]any{"d": 3}}),
+				mappath.PutOp("b.e", 4),
+			},
+			result: map[string]any{
+				"a": 1,
+				"b": map[string]any{"d": 3, "e": 4},
+			},
+		},
+		"move missing, not found": {
+			p: map[string]any{"a": 1},
+			ops: []mappath.Op{
+				mappath.MoveOp("b", "c"),
+			},
+			err: &mappath.NotFoundError{},
+		},
+		"delete missing under remembered prefix, not found": {
+			p: map[string]any{},
+			ops: []mappath.Op{
+				mappath.PutOp("a.b", 1),
+				mappath.DeleteOp("a.c"),
+			},
+			err: &mappath.NotFoundError{},
+		},
+		"invalid key": {
+			p: map[string]any{},
+			ops: []mappath.Op{
+				mappath.PutOp(".a", 1),
+			},
+			err: &mappath.InvalidPathError{},
+		},
+		"unknown kind": {
+			p: map[string]any{},
+			ops: []mappath.Op{
+				{Kind: 42, Key: "a"},
+			},
+			err: &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := mappath.Apply(test.p, test.ops...)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(result, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestApplyOrderedMap(t *testing.T) {
+	m := mappath.NewOrderedMap()
+	result, err := mappath.Apply(m,
+		mappath.PutOp("z", 1),
+		mappath.PutOp("a.b", 2),
+		mappath.PutOp("a.c", 3),
+		mappath.DeleteOp("z"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if result != m || !reflect.DeepEqual(m.Keys(), []string{"a"}) {
+		t.Errorf("unexpected result - want: [a], got: %v", m.Keys())
+	}
+}
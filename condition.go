@@ -0,0 +1,577 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type ExpressionError struct {
+	Offset int
+	Reason string
+}
+
+func (e *ExpressionError) Error() string { return fmt.Sprintf("offset %v: %v", e.Offset, e.Reason) }
+
+// Condition is a precompiled boolean expression over values resolved by Get.
+//
+// Operands are keys, string literals in double or single quotes, numbers, true, false, null,
+// lists like ["a", "b"] and exists(key) checks. Missing keys are evaluated as null.
+//
+// Supported operators, from the lowest precedence:
+//   - ||, then &&;
+//   - ! - negation;
+//   - == and != - numbers are compared by value, other values - deeply;
+//   - <, <=, > and >= - for numbers and strings, false for other values;
+//   - in and contains - element of a slice, key of a map or substring of a string;
+//   - =~ and !~ - regular expression match.
+//
+// Parentheses group expressions. A value used as a whole condition is true
+// if it is true, non-zero number, non-empty string or container.
+// Keys that look like numbers are parsed as numbers.
+//
+//	level == "error" && metadata.user.roles contains "admin"
+type Condition struct {
+	root condNode
+}
+
+// CompileCondition parses an expression for repeated use.
+func CompileCondition(expr string) (*Condition, error) {
+	tokens, err := lexCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &condParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &ExpressionError{Offset: t.pos, Reason: fmt.Sprintf("unexpected %q", t.text)}
+	}
+
+	return &Condition{root: root}, nil
+}
+
+// Eval evaluates the condition against data.
+func (c *Condition) Eval(data any) (bool, error) {
+	v, err := c.root.eval(data)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+// Eval compiles an expression and evaluates it against data.
+func Eval(expr string, data any) (bool, error) {
+	c, err := CompileCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return c.Eval(data)
+}
+
+type condNode interface {
+	eval(data any) (any, error)
+}
+
+type (
+	literalNode struct{ val any }
+	keyNode     struct{ key string }
+	listNode    struct{ items []condNode }
+	existsNode  struct{ key string }
+	notNode     struct{ x condNode }
+	andNode     struct{ l, r condNode }
+	orNode      struct{ l, r condNode }
+	cmpNode     struct {
+		op   string
+		l, r condNode
+		re   *regexp.Regexp // precompiled, if the pattern is a literal
+	}
+)
+
+func (n literalNode) eval(any) (any, error) { return n.val, nil }
+
+func (n keyNode) eval(data any) (any, error) {
+	v, err := Get(data, n.key)
+	if err != nil {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func (n listNode) eval(data any) (any, error) {
+	s := make([]any, len(n.items))
+	for i, item := range n.items {
+		v, err := item.eval(data)
+		if err != nil {
+			return nil, err
+		}
+		s[i] = v
+	}
+	return s, nil
+}
+
+func (n existsNode) eval(data any) (any, error) {
+	_, err := Get(data, n.key)
+	return err == nil, nil
+}
+
+func (n notNode) eval(data any) (any, error) {
+	v, err := n.x.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(v), nil
+}
+
+func (n andNode) eval(data any) (any, error) {
+	l, err := n.l.eval(data)
+	if err != nil || !truthy(l) {
+		return false, err
+	}
+
+	r, err := n.r.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(r), nil
+}
+
+func (n orNode) eval(data any) (any, error) {
+	l, err := n.l.eval(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if truthy(l) {
+		return true, nil
+	}
+
+	r, err := n.r.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(r), nil
+}
+
+func (n cmpNode) eval(data any) (any, error) {
+	l, err := n.l.eval(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := n.r.eval(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "<":
+		c, ok := compare(l, r)
+		return ok && c < 0, nil
+	case "<=":
+		c, ok := compare(l, r)
+		return ok && c <= 0, nil
+	case ">":
+		c, ok := compare(l, r)
+		return ok && c > 0, nil
+	case ">=":
+		c, ok := compare(l, r)
+		return ok && c >= 0, nil
+	case "in":
+		return contains(r, l), nil
+	case "contains":
+		return contains(l, r), nil
+	case "=~", "!~":
+		s, ok := l.(string)
+		if !ok {
+			return n.op == "!~", nil
+		}
+
+		re := n.re
+		if re == nil {
+			pattern, ok := r.(string)
+			if !ok {
+				return nil, &ExpressionError{Reason: fmt.Sprintf("regular expression must be a string, got %T", r)}
+			}
+
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, &ExpressionError{Reason: err.Error()}
+			}
+		}
+		return re.MatchString(s) == (n.op == "=~"), nil
+	default:
+		return nil, &ExpressionError{Reason: fmt.Sprintf("unknown operator %q", n.op)}
+	}
+}
+
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		return t != ""
+	}
+
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+
+	if l, ok := lenOf(v); ok {
+		return l > 0
+	}
+	return len(childrenOf(v)) > 0
+}
+
+func equal(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func compare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			switch {
+			case fa < fb:
+				return -1, true
+			case fa > fb:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+
+	sa, aok := a.(string)
+	sb, bok := b.(string)
+	if aok && bok {
+		return strings.Compare(sa, sb), true
+	}
+	return 0, false
+}
+
+// contains reports whether the container has the value as an element, a key or a substring.
+func contains(container, val any) bool {
+	if s, ok := container.(string); ok {
+		sub, ok := val.(string)
+		return ok && strings.Contains(s, sub)
+	}
+
+	if _, ok := lenOf(container); ok {
+		for _, k := range childrenOf(container) {
+			if elem, err := searchInNode(container, k); err == nil && equal(elem, val) {
+				return true
+			}
+		}
+		return false
+	}
+
+	key, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	_, err := searchInNode(container, key)
+	return err == nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokString
+	tokNumber
+	tokWord
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	val  any
+	pos  int
+}
+
+var condOps = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")", "[", "]", ","}
+
+func lexCondition(expr string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(expr); {
+		c := expr[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '"' || c == '\'':
+			end := pos + 1
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+
+			if end >= len(expr) {
+				return nil, &ExpressionError{Offset: pos, Reason: "unterminated string"}
+			}
+
+			s, err := unquote(expr[pos : end+1])
+			if err != nil {
+				return nil, &ExpressionError{Offset: pos, Reason: fmt.Sprintf("invalid string: %v", err)}
+			}
+
+			tokens = append(tokens, token{kind: tokString, text: expr[pos : end+1], val: s, pos: pos})
+			pos = end + 1
+		case isWordChar(c):
+			end := pos
+			for end < len(expr) && isWordChar(expr[end]) {
+				end++
+			}
+
+			word := expr[pos:end]
+			if f, err := strconv.ParseFloat(word, 64); err == nil && !unicode.IsLetter(rune(word[0])) {
+				tokens = append(tokens, token{kind: tokNumber, text: word, val: f, pos: pos})
+			} else {
+				tokens = append(tokens, token{kind: tokWord, text: word, pos: pos})
+			}
+			pos = end
+		default:
+			found := false
+			for _, op := range condOps {
+				if strings.HasPrefix(expr[pos:], op) {
+					tokens = append(tokens, token{kind: tokOp, text: op, pos: pos})
+					pos += len(op)
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return nil, &ExpressionError{Offset: pos, Reason: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(expr)}), nil
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '$' || c == '@' || c == '+' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
+
+// unquote unquotes a string literal in double or single quotes.
+func unquote(s string) (string, error) {
+	if s[0] == '\'' {
+		s = `"` + strings.ReplaceAll(strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`), `"`, `\"`) + `"`
+	}
+	return strconv.Unquote(s)
+}
+
+type condParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *condParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *condParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *condParser) isOp(t token, ops ...string) bool {
+	if t.kind == tokOp {
+		for _, op := range ops {
+			if t.text == op {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *condParser) expect(op string) error {
+	if t := p.next(); !p.isOp(t, op) {
+		return unexpected(t, fmt.Sprintf("%q", op))
+	}
+	return nil
+}
+
+func (p *condParser) parseOr() (condNode, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOp(p.peek(), "||") {
+		p.next()
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = orNode{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *condParser) parseAnd() (condNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOp(p.peek(), "&&") {
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = andNode{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *condParser) parseUnary() (condNode, error) {
+	if p.isOp(p.peek(), "!") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *condParser) parseComparison() (condNode, error) {
+	l, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if !p.isOp(t, "==", "!=", "<", "<=", ">", ">=", "=~", "!~") &&
+		!(t.kind == tokWord && (t.text == "in" || t.text == "contains")) {
+		return l, nil
+	}
+	p.next()
+
+	r, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	n := cmpNode{op: t.text, l: l, r: r}
+	if lit, ok := r.(literalNode); ok && (t.text == "=~" || t.text == "!~") {
+		pattern, ok := lit.val.(string)
+		if !ok {
+			return nil, &ExpressionError{Offset: t.pos, Reason: "regular expression must be a string"}
+		}
+
+		if n.re, err = regexp.Compile(pattern); err != nil {
+			return nil, &ExpressionError{Offset: t.pos, Reason: err.Error()}
+		}
+	}
+	return n, nil
+}
+
+func (p *condParser) parseOperand() (condNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString, tokNumber:
+		return literalNode{val: t.val}, nil
+	case tokWord:
+		switch t.text {
+		case "true":
+			return literalNode{val: true}, nil
+		case "false":
+			return literalNode{val: false}, nil
+		case "null":
+			return literalNode{val: nil}, nil
+		case "in", "contains":
+			return nil, unexpected(t, "operand")
+		case "exists":
+			if !p.isOp(p.peek(), "(") {
+				break
+			}
+			p.next()
+
+			k := p.next()
+			if k.kind != tokWord {
+				return nil, unexpected(k, "key")
+			}
+
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return existsNode{key: k.text}, nil
+		}
+
+		if t.text[0] == '.' && t.text != "." {
+			return nil, &ExpressionError{Offset: t.pos, Reason: "key cannot start from dot"}
+		}
+		return keyNode{key: t.text}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "[":
+			var items []condNode
+			for !p.isOp(p.peek(), "]") {
+				item, err := p.parseOperand()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+
+				if !p.isOp(p.peek(), ",") {
+					break
+				}
+				p.next()
+			}
+
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			return listNode{items: items}, nil
+		}
+	}
+
+	return nil, unexpected(t, "operand")
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == tokEOF {
+		return &ExpressionError{Offset: t.pos, Reason: fmt.Sprintf("unexpected end of expression, expecting %v", expected)}
+	}
+	return &ExpressionError{Offset: t.pos, Reason: fmt.Sprintf("unexpected %q, expecting %v", t.text, expected)}
+}
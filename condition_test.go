@@ -0,0 +1,150 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+var testConditionData = map[string]any{
+	"level":   "error",
+	"message": "user login failed",
+	"code":    503.0,
+	"retries": 0,
+	"ip":      nil,
+	"metadata": map[string]any{
+		"user": map[string]any{
+			"name":  "John Doe",
+			"roles": []any{"employee", "admin"},
+			"age":   42,
+		},
+	},
+}
+
+func TestEval(t *testing.T) {
+	tests := map[string]struct {
+		expr   string
+		result bool
+		err    error
+	}{
+		"equality and contains": {
+			expr:   `level == "error" && metadata.user.roles contains "admin"`,
+			result: true,
+		},
+		"numbers of different types": {
+			expr:   `metadata.user.age == 42.0 && code >= 500 && code < 600`,
+			result: true,
+		},
+		"or and not": {
+			expr:   `!(level == 'info') || retries > 3`,
+			result: true,
+		},
+		"precedence": {
+			expr:   `level == "info" && code == 503 || retries == 0`,
+			result: true,
+		},
+		"in list": {
+			expr:   `level in ["error", "fatal"]`,
+			result: true,
+		},
+		"not in list": {
+			expr:   `!(level in ["info", "debug"])`,
+			result: true,
+		},
+		"in map keys and substring": {
+			expr:   `"user" in metadata && "login" in message`,
+			result: true,
+		},
+		"exists": {
+			expr:   `exists(ip) && !exists(metadata.user.email)`,
+			result: true,
+		},
+		"missing is null": {
+			expr:   `metadata.user.email == null && ip == null`,
+			result: true,
+		},
+		"regex match": {
+			expr:   `message =~ "^user .* failed$" && metadata.user.name !~ "^Jane"`,
+			result: true,
+		},
+		"strings comparison": {
+			expr:   `metadata.user.name < "Jane"`,
+			result: false,
+		},
+		"mismatched types comparison": {
+			expr:   `level > 1`,
+			result: false,
+		},
+		"truthiness": {
+			expr:   `metadata.user.roles && !retries && !ip && message`,
+			result: true,
+		},
+		"slice index": {
+			expr:   `metadata.user.roles.-1 == "admin"`,
+			result: true,
+		},
+		"unclosed paren, expression error": {
+			expr: `(level == "error"`,
+			err:  &mappath.ExpressionError{},
+		},
+		"dangling operator, expression error": {
+			expr: `level ==`,
+			err:  &mappath.ExpressionError{},
+		},
+		"bad regex, expression error": {
+			expr: `message =~ "("`,
+			err:  &mappath.ExpressionError{},
+		},
+		"single equal sign, expression error": {
+			expr: `level = "error"`,
+			err:  &mappath.ExpressionError{},
+		},
+		"unterminated string, expression error": {
+			expr: `level == "error`,
+			err:  &mappath.ExpressionError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Eval(test.expr, testConditionData)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if val != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestCompileCondition(t *testing.T) {
+	c, err := mappath.CompileCondition(`level == "error" && exists(metadata.user)`)
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	for data, want := range map[*map[string]any]bool{
+		&testConditionData:                 true,
+		{"level": "error"}:                 false,
+		{"level": "info", "metadata": nil}: false,
+	} {
+		got, err := c.Eval(*data)
+		if err != nil {
+			t.Fatalf("unexpected error - want: nil, got: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("unexpected result for %v - want: %v, got: %v", *data, want, got)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package mappath
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConflictError is returned by conditional puts, if the current value on a path does not satisfy the condition,
+// and by TransformWithOpts, if keys of a node are renamed to the same key.
+type ConflictError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ConflictError) Error() string { return fmt.Sprintf("%v: %v", e.Path, e.Reason) }
+
+// PutIf puts a value by specified key only if the current value is equal to expected one,
+// otherwise ConflictError is returned. Numbers are compared by value, other values - deeply.
+// A missing value is a conflict too, use PutIfAbsent to create values.
+func PutIf(p any, key string, expected, val any) (any, error) {
+	return DefaultLimits.PutIf(p, key, expected, val)
+}
+
+// PutIf is the same as package PutIf, but passed limits are applied instead of DefaultLimits.
+func (l Limits) PutIf(p any, key string, expected, val any) (any, error) {
+	curr, err := Get(p, key)
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+
+		return nil, &ConflictError{
+			Path:   key,
+			Reason: "value is absent",
+		}
+	}
+
+	if !equal(curr, expected) {
+		return nil, &ConflictError{
+			Path:   key,
+			Reason: fmt.Sprintf("current value %v is not equal to expected %v", curr, expected),
+		}
+	}
+
+	return l.Put(p, key, val)
+}
+
+// PutIfAbsent puts a value by specified key only if there is no value yet, otherwise ConflictError is returned.
+// Explicit nil is a present value.
+func PutIfAbsent(p any, key string, val any) (any, error) {
+	return DefaultLimits.PutIfAbsent(p, key, val)
+}
+
+// PutIfAbsent is the same as package PutIfAbsent, but passed limits are applied instead of DefaultLimits.
+func (l Limits) PutIfAbsent(p any, key string, val any) (any, error) {
+	_, err := Get(p, key)
+	if err == nil {
+		return nil, &ConflictError{
+			Path:   key,
+			Reason: "value is already present",
+		}
+	}
+
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	return l.Put(p, key, val)
+}
+
+// PutIfPresent replaces a value by specified key only if it exists, otherwise ConflictError is returned.
+func PutIfPresent(p any, key string, val any) (any, error) {
+	return DefaultLimits.PutIfPresent(p, key, val)
+}
+
+// PutIfPresent is the same as package PutIfPresent, but passed limits are applied instead of DefaultLimits.
+func (l Limits) PutIfPresent(p any, key string, val any) (any, error) {
+	_, err := Get(p, key)
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+
+		return nil, &ConflictError{
+			Path:   key,
+			Reason: "value is absent",
+		}
+	}
+
+	return l.Put(p, key, val)
+}
+
+func isNotFound(err error) bool {
+	var notFoundError *NotFoundError
+	return errors.As(err, &notFoundError)
+}
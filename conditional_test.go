@@ -0,0 +1,91 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestConditionalPut(t *testing.T) {
+	newData := func() any {
+		return map[string]any{
+			"id":    "1",
+			"count": 2.0,
+			"note":  nil,
+			"tags":  []any{"a"},
+		}
+	}
+
+	tests := map[string]struct {
+		put    func(p any) (any, error)
+		result any
+		err    error
+	}{
+		"put if equal, ok": {
+			put: func(p any) (any, error) { return mappath.PutIf(p, "count", 2, 3) },
+			result: map[string]any{
+				"id": "1", "count": 3, "note": nil, "tags": []any{"a"},
+			},
+		},
+		"put if equal container, ok": {
+			put: func(p any) (any, error) { return mappath.PutIf(p, "tags", []any{"a"}, []any{"b"}) },
+			result: map[string]any{
+				"id": "1", "count": 2.0, "note": nil, "tags": []any{"b"},
+			},
+		},
+		"put if not equal, conflict": {
+			put: func(p any) (any, error) { return mappath.PutIf(p, "id", "2", "3") },
+			err: &mappath.ConflictError{},
+		},
+		"put if missing, conflict": {
+			put: func(p any) (any, error) { return mappath.PutIf(p, "foo", nil, "bar") },
+			err: &mappath.ConflictError{},
+		},
+		"put if absent, ok": {
+			put: func(p any) (any, error) { return mappath.PutIfAbsent(p, "meta.source", "api") },
+			result: map[string]any{
+				"id": "1", "count": 2.0, "note": nil, "tags": []any{"a"},
+				"meta": map[string]any{"source": "api"},
+			},
+		},
+		"put if absent on nil, conflict": {
+			put: func(p any) (any, error) { return mappath.PutIfAbsent(p, "note", "foo") },
+			err: &mappath.ConflictError{},
+		},
+		"put if absent, invalid path": {
+			put: func(p any) (any, error) { return mappath.PutIfAbsent(p, ".foo", "bar") },
+			err: &mappath.InvalidPathError{},
+		},
+		"put if present, ok": {
+			put: func(p any) (any, error) { return mappath.PutIfPresent(p, "tags.0", "b") },
+			result: map[string]any{
+				"id": "1", "count": 2.0, "note": nil, "tags": []any{"b"},
+			},
+		},
+		"put if present, conflict": {
+			put: func(p any) (any, error) { return mappath.PutIfPresent(p, "tags.1", "b") },
+			err: &mappath.ConflictError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.put(newData())
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(result, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, result)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package mappath
 
 // Container stores data and updates it only if change operations have been performed successfully.
+//
+// Container is not safe for concurrent use. Conditional puts and update helpers check and change data
+// in a single call, so a container shared between goroutines becomes consistent by guarding calls with a mutex.
 type Container struct {
 	Data any
 }
@@ -10,23 +13,72 @@ func (c *Container) Get(key string) (any, error) {
 }
 
 func (c *Container) Put(key string, val any) error {
-	data, err := Put(c.Data, key, val)
-	if err != nil {
-		return err
-	}
+	return c.WithLimits(DefaultLimits).Put(key, val)
+}
 
-	c.Data = data
-	return nil
+// PutIf is the same as package PutIf. The check and the put are performed in a single call.
+func (c *Container) PutIf(key string, expected, val any) error {
+	return c.WithLimits(DefaultLimits).PutIf(key, expected, val)
+}
+
+// PutIfAbsent is the same as package PutIfAbsent. The check and the put are performed in a single call.
+func (c *Container) PutIfAbsent(key string, val any) error {
+	return c.WithLimits(DefaultLimits).PutIfAbsent(key, val)
+}
+
+// PutIfPresent is the same as package PutIfPresent. The check and the put are performed in a single call.
+func (c *Container) PutIfPresent(key string, val any) error {
+	return c.WithLimits(DefaultLimits).PutIfPresent(key, val)
+}
+
+// Increment is the same as package Increment.
+func (c *Container) Increment(key string, delta any) error {
+	return c.WithLimits(DefaultLimits).Increment(key, delta)
+}
+
+// Toggle is the same as package Toggle.
+func (c *Container) Toggle(key string) error {
+	return c.WithLimits(DefaultLimits).Toggle(key)
+}
+
+// AppendTo is the same as package AppendTo.
+func (c *Container) AppendTo(key string, vals ...any) error {
+	return c.WithLimits(DefaultLimits).AppendTo(key, vals...)
+}
+
+// Prepend is the same as package Prepend.
+func (c *Container) Prepend(key string, vals ...any) error {
+	return c.WithLimits(DefaultLimits).Prepend(key, vals...)
+}
+
+// AddToSet is the same as package AddToSet.
+func (c *Container) AddToSet(key string, vals ...any) error {
+	return c.WithLimits(DefaultLimits).AddToSet(key, vals...)
+}
+
+// RemoveFromSlice is the same as package RemoveFromSlice.
+func (c *Container) RemoveFromSlice(key string, val any) error {
+	return c.WithLimits(DefaultLimits).RemoveFromSlice(key, val)
 }
 
 func (c *Container) Delete(key string) error {
-	data, err := Delete(c.Data, key)
-	if err != nil {
-		return err
-	}
+	return c.WithLimits(DefaultLimits).Delete(key)
+}
 
-	c.Data = data
-	return nil
+// DeleteWithOpts is the same as package DeleteWithOpts.
+func (c *Container) DeleteWithOpts(key string, opts DeleteOpts) error {
+	return c.WithLimits(DefaultLimits).DeleteWithOpts(key, opts)
+}
+
+// DeleteAll is the same as package DeleteAll, data is not changed if any key does not exist.
+func (c *Container) DeleteAll(keys ...string) error {
+	return c.WithLimits(DefaultLimits).DeleteAll(keys...)
+}
+
+// Apply is the same as package Apply. Data is replaced only if all operations succeed,
+// but maps and slices may already be modified in place by previous operations.
+func (c *Container) Apply(ops ...Op) error {
+	return c.WithLimits(DefaultLimits).Apply(ops...)
 }
 
 func (c *Container) Clone() *Container {
@@ -34,3 +86,108 @@ func (c *Container) Clone() *Container {
 	cc.Data = Clone(c.Data)
 	return cc
 }
+
+// WithLimits returns a view of the container, that applies passed limits to keys and data changes
+// instead of DefaultLimits. Changes made through the view are stored in the container.
+func (c *Container) WithLimits(l Limits) *LimitedContainer {
+	return &LimitedContainer{container: c, limits: l}
+}
+
+// LimitedContainer is a view of a Container with its own limits, it is created by Container.WithLimits.
+// Container methods, that change data, are performed by a view with DefaultLimits.
+type LimitedContainer struct {
+	container *Container
+	limits    Limits
+}
+
+// Container returns the container, that stores data changed through the view.
+func (c *LimitedContainer) Container() *Container {
+	return c.container
+}
+
+func (c *LimitedContainer) Get(key string) (any, error) {
+	return Get(c.container.Data, key)
+}
+
+func (c *LimitedContainer) Put(key string, val any) error {
+	return c.update(c.limits.Put(c.container.Data, key, val))
+}
+
+// PutIf is the same as Container.PutIf.
+func (c *LimitedContainer) PutIf(key string, expected, val any) error {
+	return c.update(c.limits.PutIf(c.container.Data, key, expected, val))
+}
+
+// PutIfAbsent is the same as Container.PutIfAbsent.
+func (c *LimitedContainer) PutIfAbsent(key string, val any) error {
+	return c.update(c.limits.PutIfAbsent(c.container.Data, key, val))
+}
+
+// PutIfPresent is the same as Container.PutIfPresent.
+func (c *LimitedContainer) PutIfPresent(key string, val any) error {
+	return c.update(c.limits.PutIfPresent(c.container.Data, key, val))
+}
+
+// Increment is the same as Container.Increment.
+func (c *LimitedContainer) Increment(key string, delta any) error {
+	return c.update(c.limits.Increment(c.container.Data, key, delta))
+}
+
+// Toggle is the same as Container.Toggle.
+func (c *LimitedContainer) Toggle(key string) error {
+	return c.update(c.limits.Toggle(c.container.Data, key))
+}
+
+// AppendTo is the same as Container.AppendTo.
+func (c *LimitedContainer) AppendTo(key string, vals ...any) error {
+	return c.update(c.limits.AppendTo(c.container.Data, key, vals...))
+}
+
+// Prepend is the same as Container.Prepend.
+func (c *LimitedContainer) Prepend(key string, vals ...any) error {
+	return c.update(c.limits.Prepend(c.container.Data, key, vals...))
+}
+
+// AddToSet is the same as Container.AddToSet.
+func (c *LimitedContainer) AddToSet(key string, vals ...any) error {
+	return c.update(c.limits.AddToSet(c.container.Data, key, vals...))
+}
+
+// RemoveFromSlice is the same as Container.RemoveFromSlice.
+func (c *LimitedContainer) RemoveFromSlice(key string, val any) error {
+	return c.update(c.limits.RemoveFromSlice(c.container.Data, key, val))
+}
+
+func (c *LimitedContainer) Delete(key string) error {
+	return c.update(c.limits.Delete(c.container.Data, key))
+}
+
+// DeleteWithOpts is the same as Container.DeleteWithOpts.
+func (c *LimitedContainer) DeleteWithOpts(key string, opts DeleteOpts) error {
+	return c.update(c.limits.DeleteWithOpts(c.container.Data, key, opts))
+}
+
+// DeleteAll is the same as Container.DeleteAll.
+func (c *LimitedContainer) DeleteAll(keys ...string) error {
+	return c.update(c.limits.DeleteAll(c.container.Data, keys...))
+}
+
+// Apply is the same as Container.Apply.
+func (c *LimitedContainer) Apply(ops ...Op) error {
+	return c.update(c.limits.Apply(c.container.Data, ops...))
+}
+
+// Clone returns a view of a cloned container with the same limits.
+func (c *LimitedContainer) Clone() *LimitedContainer {
+	return c.container.Clone().WithLimits(c.limits)
+}
+
+// update stores data in the container, if the operation has been performed successfully.
+func (c *LimitedContainer) update(data any, err error) error {
+	if err != nil {
+		return err
+	}
+
+	c.container.Data = data
+	return nil
+}
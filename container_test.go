@@ -2,6 +2,8 @@ package mappath_test
 
 import (
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/gekatateam/mappath"
@@ -569,3 +571,144 @@ func TestContainerDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerConditionalPut(t *testing.T) {
+	c := &mappath.Container{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var wins atomic.Int32
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			if err := c.PutIfAbsent("owner", i); err == nil {
+				wins.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if wins.Load() != 1 {
+		t.Errorf("unexpected successful puts - want: 1, got: %v", wins.Load())
+	}
+
+	owner, _ := c.Get("owner")
+	if err := c.PutIf("owner", -1, "nobody"); err == nil {
+		t.Errorf("unexpected error - want: ConflictError, got: nil")
+	}
+
+	if err := c.PutIf("owner", owner, "somebody"); err != nil {
+		t.Errorf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if err := c.PutIfPresent("owner", "anybody"); err != nil {
+		t.Errorf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{"owner": "anybody"}
+	if !reflect.DeepEqual(c.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, c.Data)
+	}
+}
+
+func TestContainerUpdate(t *testing.T) {
+	c := &mappath.Container{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			_ = c.Increment("stats.hits", 1)
+			_ = c.AddToSet("tags", "seen")
+		}()
+	}
+	wg.Wait()
+
+	_ = c.Toggle("stats.done")
+	_ = c.AppendTo("tags", "last")
+	_ = c.Prepend("tags", "first")
+	_ = c.RemoveFromSlice("tags", "seen")
+
+	if err := c.Increment("tags", 1); err == nil {
+		t.Errorf("unexpected error - want: ConversionError, got: nil")
+	}
+
+	want := map[string]any{
+		"stats": map[string]any{"hits": 100, "done": true},
+		"tags":  []any{"first", "last"},
+	}
+	if !reflect.DeepEqual(c.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, c.Data)
+	}
+}
+
+func TestContainerDeleteWithOpts(t *testing.T) {
+	c := &mappath.Container{Data: map[string]any{
+		"foo": "bar",
+		"a":   map[string]any{"b": map[string]any{"c": 1}},
+	}}
+
+	if err := c.DeleteWithOpts("a.b.c", mappath.DeleteOpts{PruneEmpty: true}); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{"foo": "bar"}
+	if !reflect.DeepEqual(c.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, c.Data)
+	}
+
+	if err := c.DeleteWithOpts("a.b", mappath.DeleteOpts{PruneEmpty: true}); err == nil {
+		t.Errorf("unexpected error - want: NotFoundError, got: nil")
+	}
+}
+
+func TestContainerDeleteAll(t *testing.T) {
+	c := &mappath.Container{Data: map[string]any{
+		"items": []any{"a", "b", "c", "d"},
+	}}
+
+	if err := c.DeleteAll("items.4", "items.0"); err == nil {
+		t.Errorf("unexpected error - want: NotFoundError, got: nil")
+	}
+
+	if err := c.DeleteAll("items.1", "items.3"); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{"items": []any{"a", "c"}}
+	if !reflect.DeepEqual(c.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, c.Data)
+	}
+}
+
+func TestContainerApply(t *testing.T) {
+	c := &mappath.Container{}
+
+	err := c.Apply(
+		mappath.PutOp("metadata.user.login", "johndoe12"),
+		mappath.CopyOp("metadata.user.login", "metadata.user.name"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if err := c.Apply(mappath.MoveOp("foo", "bar")); err == nil {
+		t.Errorf("unexpected error - want: NotFoundError, got: nil")
+	}
+
+	want := map[string]any{
+		"metadata": map[string]any{
+			"user": map[string]any{"login": "johndoe12", "name": "johndoe12"},
+		},
+	}
+	if !reflect.DeepEqual(c.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, c.Data)
+	}
+}
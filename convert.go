@@ -0,0 +1,126 @@
+package mappath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type ConversionError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ConversionError) Error() string { return fmt.Sprintf("%v: %v", e.Path, e.Reason) }
+
+// convert converts a value into one of supported types: string, int, float or bool.
+func convert(path string, val any, typ string) (any, error) {
+	switch typ {
+	case "":
+		return val, nil
+	case "string":
+		return toString(val), nil
+	case "int":
+		if s, ok := val.(string); ok {
+			if i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 0); err == nil {
+				return int(i), nil
+			}
+		}
+
+		// float64(math.MaxInt) is rounded up to 2^63, that is out of int range
+		if f, ok := toFloat(val); ok && f == math.Trunc(f) && f >= math.MinInt && f < math.MaxInt {
+			return int(f), nil
+		}
+	case "float":
+		if s, ok := val.(string); ok {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f, nil
+			}
+		}
+
+		if f, ok := toFloat(val); ok {
+			return f, nil
+		}
+	case "bool":
+		switch t := val.(type) {
+		case bool:
+			return t, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(t)); err == nil {
+				return b, nil
+			}
+		}
+	default:
+		return nil, checkType(path, typ)
+	}
+
+	return nil, &ConversionError{
+		Path:   path,
+		Reason: fmt.Sprintf("value %v of type %T cannot be converted into %v", val, val, typ),
+	}
+}
+
+// checkType returns an error if the type is not supported by convert.
+func checkType(path, typ string) error {
+	switch typ {
+	case "", "string", "int", "float", "bool":
+		return nil
+	default:
+		return &ConversionError{
+			Path:   path,
+			Reason: fmt.Sprintf("unknown type %q, must be one of string, int, float or bool", typ),
+		}
+	}
+}
+
+// toFloat converts any Go number or json.Number into float64.
+func toFloat(val any) (float64, bool) {
+	switch t := val.(type) {
+	case float64:
+		return t, true
+	case int:
+		return float64(t), true
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	case nil, string, bool:
+		return 0, false
+	}
+
+	v := reflect.ValueOf(val)
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// toString returns a string representation of a value: strings as is, containers as JSON.
+func toString(val any) string {
+	switch t := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case fmt.Stringer:
+		return t.String()
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(val)).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if data, err := json.Marshal(val); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(val)
+}
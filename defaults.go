@@ -0,0 +1,54 @@
+package mappath
+
+type DefaultsOpts struct {
+	// ReplaceNil makes explicit nil values be replaced by defaults, as if they were missing.
+	ReplaceNil bool
+}
+
+// ApplyDefaults inserts values from defaults tree into the document only where the corresponding path is missing,
+// present values, including explicit nils, are never overwritten. Nested objects are merged key by key,
+// slices are treated as leaves. Inserted values are cloned, so the defaults tree may be reused.
+//
+// Unlike the dot merge by Put, which overwrites existing keys, this is suitable for filling configs.
+// Struct fields are always present, so only containers in them are filled. Defaults, that cannot be put, are skipped.
+// A nil document is replaced by a clone of defaults.
+func ApplyDefaults(doc, defaults any) any {
+	return ApplyDefaultsWithOpts(doc, defaults, DefaultsOpts{})
+}
+
+// ApplyDefaultsWithOpts is the same as ApplyDefaults, but configured by provided options.
+func ApplyDefaultsWithOpts(doc, defaults any, opts DefaultsOpts) any {
+	if doc == nil {
+		return Clone(defaults)
+	}
+	return applyDefaults(doc, defaults, opts)
+}
+
+func applyDefaults(doc, defaults any, opts DefaultsOpts) any {
+	if typeOf(doc) != "object" || typeOf(defaults) != "object" {
+		return doc
+	}
+
+	for _, k := range childrenOf(defaults) {
+		def, err := searchInNode(defaults, k)
+		if err != nil {
+			continue
+		}
+
+		val, err := searchInNode(doc, k)
+		switch {
+		case err != nil:
+			val = Clone(def)
+		case val == nil && opts.ReplaceNil:
+			val = Clone(def)
+		default:
+			val = applyDefaults(val, def, opts)
+		}
+
+		if d, err := putInNode(doc, k, val); err == nil {
+			doc = d
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,122 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	defaults := map[string]any{
+		"host": "localhost",
+		"port": 8080,
+		"tls": map[string]any{
+			"enabled": false,
+			"ciphers": []any{"a", "b"},
+		},
+		"tags":    []any{"default"},
+		"timeout": "30s",
+	}
+
+	tests := map[string]struct {
+		doc    any
+		opts   mappath.DefaultsOpts
+		result any
+	}{
+		"nil document": {
+			doc:    nil,
+			result: defaults,
+		},
+		"nested merge without overwrites": {
+			doc: map[string]any{
+				"port": 9090,
+				"tls": map[string]any{
+					"enabled": true,
+				},
+				"tags":    []any{"custom"},
+				"timeout": nil,
+			},
+			result: map[string]any{
+				"host": "localhost",
+				"port": 9090,
+				"tls": map[string]any{
+					"enabled": true,
+					"ciphers": []any{"a", "b"},
+				},
+				"tags":    []any{"custom"},
+				"timeout": nil,
+			},
+		},
+		"replace nils": {
+			doc: map[string]any{
+				"tls":     nil,
+				"timeout": nil,
+			},
+			opts: mappath.DefaultsOpts{ReplaceNil: true},
+			result: map[string]any{
+				"host": "localhost",
+				"port": 8080,
+				"tls": map[string]any{
+					"enabled": false,
+					"ciphers": []any{"a", "b"},
+				},
+				"tags":    []any{"default"},
+				"timeout": "30s",
+			},
+		},
+		"type mismatch keeps document value": {
+			doc: map[string]any{
+				"tls": "on",
+			},
+			result: map[string]any{
+				"host":    "localhost",
+				"port":    8080,
+				"tls":     "on",
+				"tags":    []any{"default"},
+				"timeout": "30s",
+			},
+		},
+		"ordered map": {
+			doc: func() any {
+				m := mappath.NewOrderedMap()
+				m.Set("port", 1)
+				return m
+			}(),
+			result: func() any {
+				m := mappath.NewOrderedMap()
+				m.Set("port", 1)
+				m.Set("host", "localhost")
+				m.Set("tags", []any{"default"})
+				m.Set("timeout", "30s")
+				m.Set("tls", map[string]any{
+					"enabled": false,
+					"ciphers": []any{"a", "b"},
+				})
+				return m
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := mappath.ApplyDefaultsWithOpts(test.doc, defaults, test.opts)
+			if !reflect.DeepEqual(result, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultsClonesValues(t *testing.T) {
+	defaults := map[string]any{"tls": map[string]any{"enabled": false}}
+
+	result := mappath.ApplyDefaults(map[string]any{}, defaults)
+	if _, err := mappath.Put(result, "tls.enabled", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, _ := mappath.Get(defaults, "tls.enabled"); v != false {
+		t.Errorf("defaults are modified - want: false, got: %v", v)
+	}
+}
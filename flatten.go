@@ -0,0 +1,150 @@
+package mappath
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type FlattenOpts struct {
+	// Separator joins keys of nested nodes, dot by default.
+	Separator string
+	// KeepSlices stores slices as values instead of flattening their elements.
+	KeepSlices bool
+	// Escape prefixes separators and backslashes inside keys with a backslash,
+	// so keys containing a separator survive Unflatten.
+	Escape bool
+}
+
+func (o FlattenOpts) separator() string {
+	if o.Separator == "" {
+		return "."
+	}
+	return o.Separator
+}
+
+// Flatten converts nested data into a flat map, where keys are paths of leaves
+// (scalars and empty containers) in the same syntax Get accepts. A scalar root is stored by a dot key.
+//
+//	{"metadata": {"user": {"roles": ["employee"]}}} -> {"metadata.user.roles.0": "employee"}
+//
+// Keys are not escaped, so a key containing a separator may produce the same path as nested keys,
+// and only one of their values is kept, e.g. both values of {"a.b": 1, "a": {"b": 2}} are flattened by `a.b` key.
+// Use FlattenWithOpts with Escape, if keys may contain a separator.
+func Flatten(p any) map[string]any {
+	return FlattenWithOpts(p, FlattenOpts{})
+}
+
+// FlattenWithOpts is the same as Flatten, but keys are built according to provided options.
+// Without Escape, values of colliding paths are lost the same way.
+func FlattenWithOpts(p any, opts FlattenOpts) map[string]any {
+	m := make(map[string]any)
+	flatten(m, "", p, opts)
+	return m
+}
+
+func flatten(m map[string]any, prefix string, p any, opts FlattenOpts) {
+	keys := childrenOf(p)
+	if len(keys) == 0 || (opts.KeepSlices && isSlice(p)) {
+		if prefix == "" {
+			prefix = "."
+		}
+		m[prefix] = p
+		return
+	}
+
+	for _, k := range keys {
+		child, err := searchInNode(p, k)
+		if err != nil {
+			continue
+		}
+
+		if opts.Escape {
+			k = escapeKey(k, opts.separator())
+		}
+
+		if prefix != "" {
+			k = prefix + opts.separator() + k
+		}
+		flatten(m, k, child, opts)
+	}
+}
+
+// Unflatten rebuilds nested data from a flat map produced by Flatten.
+// Keys are applied in sorted order with the same rules as Put uses, so numeric keys create slices.
+func Unflatten(m map[string]any) (any, error) {
+	return UnflattenWithOpts(m, FlattenOpts{})
+}
+
+// UnflattenWithOpts is the same as Unflatten, but keys are parsed according to provided options.
+func UnflattenWithOpts(m map[string]any, opts FlattenOpts) (any, error) {
+	var (
+		p   any
+		err error
+	)
+
+	if v, ok := m["."]; ok {
+		p = v
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		if k == "." {
+			continue
+		}
+
+		var path []string
+		if opts.Escape {
+			path = splitEscaped(k, opts.separator())
+		} else {
+			path = strings.Split(k, opts.separator())
+		}
+
+		if p, err = DefaultLimits.putInPath(p, path, m[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func isSlice(p any) bool {
+	if _, ok := p.([]any); ok {
+		return true
+	}
+
+	k := reflect.Indirect(reflect.ValueOf(p)).Kind()
+	return k == reflect.Slice || k == reflect.Array
+}
+
+func escapeKey(key, sep string) string {
+	key = strings.ReplaceAll(key, `\`, `\\`)
+	return strings.ReplaceAll(key, sep, `\`+sep)
+}
+
+func splitEscaped(key, sep string) []string {
+	var (
+		path []string
+		b    strings.Builder
+	)
+
+	for i := 0; i < len(key); {
+		switch {
+		case key[i] == '\\' && strings.HasPrefix(key[i+1:], sep):
+			b.WriteString(sep)
+			i += 1 + len(sep)
+		case key[i] == '\\' && i+1 < len(key):
+			b.WriteByte(key[i+1])
+			i += 2
+		case strings.HasPrefix(key[i:], sep):
+			path = append(path, b.String())
+			b.Reset()
+			i += len(sep)
+		default:
+			b.WriteByte(key[i])
+			i++
+		}
+	}
+
+	return append(path, b.String())
+}
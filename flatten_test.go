@@ -0,0 +1,185 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		opts   mappath.FlattenOpts
+		result map[string]any
+	}{
+		"nested map and slices": {
+			p: map[string]any{
+				"message": "user login",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"roles": []any{"employee", "manager"},
+						"tags":  map[string]any{},
+					},
+				},
+			},
+			opts: mappath.FlattenOpts{},
+			result: map[string]any{
+				"message":               "user login",
+				"metadata.user.roles.0": "employee",
+				"metadata.user.roles.1": "manager",
+				"metadata.user.tags":    map[string]any{},
+			},
+		},
+		"custom separator, slices kept": {
+			p: map[string]any{
+				"metadata": map[string]any{
+					"roles": []any{"employee", "manager"},
+				},
+			},
+			opts: mappath.FlattenOpts{Separator: "/", KeepSlices: true},
+			result: map[string]any{
+				"metadata/roles": []any{"employee", "manager"},
+			},
+		},
+		"escaped keys": {
+			p: map[string]any{
+				"k8s.io/name": map[string]any{
+					`back\slash`: 1,
+				},
+			},
+			opts: mappath.FlattenOpts{Escape: true},
+			result: map[string]any{
+				`k8s\.io/name.back\\slash`: 1,
+			},
+		},
+		"scalar root": {
+			p:    42,
+			opts: mappath.FlattenOpts{},
+			result: map[string]any{
+				".": 42,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := mappath.FlattenWithOpts(test.p, test.opts)
+			if !reflect.DeepEqual(got, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, got)
+			}
+		})
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	tests := map[string]struct {
+		m      map[string]any
+		opts   mappath.FlattenOpts
+		result any
+		err    error
+	}{
+		"nested map and slices": {
+			m: map[string]any{
+				"message":               "user login",
+				"metadata.user.roles.1": "manager",
+				"metadata.user.roles.0": "employee",
+				"metadata.user.tags":    map[string]any{},
+			},
+			opts: mappath.FlattenOpts{},
+			result: map[string]any{
+				"message": "user login",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"roles": []any{"employee", "manager"},
+						"tags":  map[string]any{},
+					},
+				},
+			},
+			err: nil,
+		},
+		"slice root": {
+			m: map[string]any{
+				"1.name": "bar",
+				"0.name": "foo",
+			},
+			opts: mappath.FlattenOpts{},
+			result: []any{
+				map[string]any{"name": "foo"},
+				map[string]any{"name": "bar"},
+			},
+			err: nil,
+		},
+		"escaped keys, custom separator": {
+			m: map[string]any{
+				`k8s.io\/name/back\\slash`: 1,
+			},
+			opts: mappath.FlattenOpts{Separator: "/", Escape: true},
+			result: map[string]any{
+				"k8s.io/name": map[string]any{
+					`back\slash`: 1,
+				},
+			},
+			err: nil,
+		},
+		"scalar root": {
+			m: map[string]any{
+				".": 42,
+			},
+			opts:   mappath.FlattenOpts{},
+			result: 42,
+			err:    nil,
+		},
+		"mixed root, invalid path": {
+			m: map[string]any{
+				"0":   "foo",
+				"bar": "buzz",
+			},
+			opts:   mappath.FlattenOpts{},
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.UnflattenWithOpts(test.m, test.opts)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestFlattenRoundTrip(t *testing.T) {
+	data := map[string]any{
+		"a.b": []any{map[string]any{"c/d": 1}, nil, "x"},
+		"e":   map[string]any{`f\g`: true},
+	}
+
+	for _, opts := range []mappath.FlattenOpts{
+		{Escape: true},
+		{Escape: true, Separator: "/"},
+		{Escape: true, Separator: "::", KeepSlices: true},
+	} {
+		got, err := mappath.UnflattenWithOpts(mappath.FlattenWithOpts(data, opts), opts)
+		if err != nil {
+			t.Fatalf("unexpected error with %+v - want: nil, got: %v", opts, err)
+		}
+
+		if !reflect.DeepEqual(got, data) {
+			t.Errorf("unexpected result with %+v - want: %v, got: %v", opts, data, got)
+		}
+	}
+}
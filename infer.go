@@ -0,0 +1,103 @@
+package mappath
+
+import (
+	"slices"
+)
+
+// InferSchema produces a structural schema, that all passed samples satisfy.
+//
+// Types of values on the same path are merged, integers are widened to numbers, if both are met.
+// Object properties present in all samples are marked as required, array element schemas
+// are merged into a single items schema. No samples produce an empty schema, that accepts any value.
+func InferSchema(samples ...any) *Schema {
+	var s *Schema
+	for _, sample := range samples {
+		s = mergeSchemas(s, schemaOf(sample))
+	}
+
+	if s == nil {
+		return &Schema{}
+	}
+	return s
+}
+
+func schemaOf(p any) *Schema {
+	typ := typeOf(p)
+	s := &Schema{Type: SchemaType{typ}}
+
+	switch typ {
+	case "object":
+		s.Properties = make(map[string]*Schema)
+		for _, k := range childrenOf(p) {
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+
+			s.Properties[k] = schemaOf(child)
+			s.Required = append(s.Required, k)
+		}
+		slices.Sort(s.Required)
+	case "array":
+		for _, k := range childrenOf(p) {
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+			s.Items = mergeSchemas(s.Items, schemaOf(child))
+		}
+	}
+
+	return s
+}
+
+// mergeSchemas returns a schema, that accepts values of both schemas. Nil schema means no values.
+func mergeSchemas(a, b *Schema) *Schema {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+
+	s := &Schema{
+		Items: mergeSchemas(a.Items, b.Items),
+	}
+
+	for _, t := range schemaTypes { // keeps types order stable
+		if slices.Contains(a.Type, t) || slices.Contains(b.Type, t) {
+			s.Type = append(s.Type, t)
+		}
+	}
+
+	if slices.Contains(s.Type, "number") {
+		s.Type = slices.DeleteFunc(s.Type, func(t string) bool { return t == "integer" })
+	}
+
+	aObject, bObject := slices.Contains(a.Type, "object"), slices.Contains(b.Type, "object")
+	switch {
+	case aObject && bObject:
+		s.Properties = make(map[string]*Schema, len(a.Properties))
+		for k, sub := range a.Properties {
+			s.Properties[k] = mergeSchemas(sub, b.Properties[k])
+		}
+
+		for k, sub := range b.Properties {
+			if _, ok := s.Properties[k]; !ok {
+				s.Properties[k] = sub
+			}
+		}
+
+		for _, r := range a.Required {
+			if slices.Contains(b.Required, r) {
+				s.Required = append(s.Required, r)
+			}
+		}
+	case aObject:
+		s.Properties, s.Required = a.Properties, a.Required
+	case bObject:
+		s.Properties, s.Required = b.Properties, b.Required
+	}
+
+	return s
+}
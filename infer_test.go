@@ -0,0 +1,88 @@
+package mappath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestInferSchema(t *testing.T) {
+	tests := map[string]struct {
+		samples []any
+		result  string
+	}{
+		"no samples": {
+			samples: nil,
+			result:  `{}`,
+		},
+		"scalar": {
+			samples: []any{"foo"},
+			result:  `{"type":"string"}`,
+		},
+		"integers widened to numbers": {
+			samples: []any{1, 2.5, nil},
+			result:  `{"type":["null","number"]}`,
+		},
+		"optional and required properties": {
+			samples: []any{
+				map[string]any{"id": 1.0, "level": "info", "tags": []any{"a", "b"}},
+				map[string]any{"id": 2.0, "message": "hello", "tags": []any{}},
+			},
+			result: `{"type":"object","required":["id","tags"],"properties":{` +
+				`"id":{"type":"integer"},` +
+				`"level":{"type":"string"},` +
+				`"message":{"type":"string"},` +
+				`"tags":{"type":"array","items":{"type":"string"}}}}`,
+		},
+		"array element types": {
+			samples: []any{
+				[]any{map[string]any{"a": 1}, map[string]any{"a": "x", "b": true}},
+			},
+			result: `{"type":"array","items":{"type":"object","required":["a"],"properties":{` +
+				`"a":{"type":["string","integer"]},` +
+				`"b":{"type":"boolean"}}}}`,
+		},
+		"structs": {
+			samples: []any{testUser{Name: "John", Roles: []string{"admin"}}},
+			result: `{"type":"object","required":["Nick","age","email","labels","name","roles"],"properties":{` +
+				`"Nick":{"type":"string"},` +
+				`"age":{"type":"integer"},` +
+				`"email":{"type":"string"},` +
+				`"labels":{"type":"null"},` +
+				`"name":{"type":"string"},` +
+				`"roles":{"type":"array","items":{"type":"string"}}}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(mappath.InferSchema(test.samples...))
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+
+			if string(data) != test.result {
+				t.Errorf("unexpected result - want: %v, got: %s", test.result, data)
+			}
+		})
+	}
+}
+
+func TestInferSchemaValidates(t *testing.T) {
+	samples := []any{
+		map[string]any{"id": 1, "user": map[string]any{"login": "john"}, "roles": []any{"admin"}},
+		map[string]any{"id": 2, "user": map[string]any{"login": "jane", "age": 30}},
+	}
+
+	schema := mappath.InferSchema(samples...)
+	for _, s := range samples {
+		if err := schema.Validate(s); err != nil {
+			t.Errorf("unexpected error - want: nil, got: %v", err)
+		}
+	}
+
+	if err := schema.Validate(map[string]any{"id": "3"}); err == nil {
+		t.Errorf("unexpected error - want: error, got: nil")
+	}
+}
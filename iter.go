@@ -0,0 +1,172 @@
+package mappath
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// errStopIteration is used to stop Walk when iteration is finished by a caller.
+var errStopIteration = errors.New("stop iteration")
+
+// All returns an iterator over all nodes of passed data with their paths, in the same order as Walk visits them.
+// The root node is yielded first with a dot path.
+//
+// Iterators cannot return errors, so if a child cannot be read, e.g. Node.Child fails, iteration ends early.
+// Use Walk to get such errors.
+func All(p any) iter.Seq2[string, any] {
+	return walkSeq(p, WalkOpts{})
+}
+
+// Leaves returns an iterator over nodes without children - scalars and empty containers - with their paths.
+// Like All, it ends early if a child cannot be read.
+func Leaves(p any) iter.Seq2[string, any] {
+	return walkSeq(p, WalkOpts{LeavesOnly: true})
+}
+
+// Keys returns an iterator over keys of node children by specified key.
+// If there is no such node, or it has no children, the iterator yields nothing.
+func Keys(p any, key string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		node, err := Get(p, key)
+		if err != nil {
+			return
+		}
+
+		for _, k := range childrenOf(node) {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Match returns an iterator over nodes, which paths match the pattern.
+//
+// Pattern has the same syntax as a key, but a `*` segment matches any single key
+// and a `**` segment matches zero or more keys. For example, `items.*.price`
+// matches prices of all items and `**.password` matches passwords on any level.
+// Yielded paths can be passed to Get, Put or Delete as is. Each matched path is yielded once,
+// children that cannot be read are skipped.
+func Match(p any, pattern string) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		if pattern == "." {
+			yield(".", p)
+			return
+		}
+
+		if len(pattern) == 0 || pattern[0] == '.' {
+			return
+		}
+
+		segs := slices.CompactFunc(strings.Split(pattern, "."), func(a, b string) bool { // `**.**` is the same as `**`
+			return a == "**" && b == "**"
+		})
+
+		var anyLevel int
+		for _, seg := range segs {
+			if seg == "**" {
+				anyLevel++
+			}
+		}
+
+		// several `**` segments may match the same path in different ways
+		if anyLevel > 1 {
+			seen := make(map[string]struct{})
+			next := yield
+			yield = func(path string, val any) bool {
+				if _, ok := seen[path]; ok {
+					return true
+				}
+				seen[path] = struct{}{}
+				return next(path, val)
+			}
+		}
+
+		match(".", p, segs, yield)
+	}
+}
+
+func walkSeq(p any, opts WalkOpts) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		// the error is either errStopIteration or a traversal one, that cannot be passed to the caller
+		_ = WalkWithOpts(p, func(path string, val any) error {
+			if !yield(path, val) {
+				return errStopIteration
+			}
+			return nil
+		}, opts)
+	}
+}
+
+// match yields nodes matching pattern segments and reports whether iteration should continue.
+func match(path string, p any, segs []string, yield func(string, any) bool) bool {
+	if len(segs) == 0 {
+		return yield(path, p)
+	}
+
+	switch seg := segs[0]; seg {
+	case "*":
+		for _, k := range childrenOf(p) {
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+
+			if !match(joinPath(path, k), child, segs[1:], yield) {
+				return false
+			}
+		}
+		return true
+	case "**":
+		if !match(path, p, segs[1:], yield) {
+			return false
+		}
+
+		for _, k := range childrenOf(p) {
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+
+			if !match(joinPath(path, k), child, segs, yield) {
+				return false
+			}
+		}
+		return true
+	default:
+		child, err := searchInNode(p, seg)
+		if err != nil {
+			return true
+		}
+		return match(joinPath(path, seg), child, segs[1:], yield)
+	}
+}
+
+// isPattern reports whether the key contains wildcard segments.
+func isPattern(key string) bool {
+	for _, seg := range strings.Split(key, ".") {
+		if seg == "*" || seg == "**" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTopmost collects paths matching the pattern, omitting paths nested into already matched ones.
+func matchTopmost(p any, pattern string) []string {
+	var paths []string
+	for path := range Match(p, pattern) {
+		if len(paths) > 0 && isNestedPath(paths[len(paths)-1], path) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// isNestedPath reports whether path is equal to parent or lies under it.
+func isNestedPath(parent, path string) bool {
+	return parent == "." || path == parent || strings.HasPrefix(path, parent+".")
+}
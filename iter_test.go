@@ -0,0 +1,159 @@
+package mappath_test
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+var testIterData = map[string]any{
+	"message": "user login",
+	"metadata": map[string]any{
+		"user": map[string]any{
+			"name":     "John Doe",
+			"password": "secret",
+		},
+		"password": "secret too",
+	},
+	"items": []any{
+		map[string]any{"price": 10, "name": "foo"},
+		map[string]any{"name": "bar"},
+		map[string]any{"price": 30},
+	},
+}
+
+func TestAll(t *testing.T) {
+	got := maps.Collect(mappath.All(map[string]any{"a": []any{1}}))
+	want := map[string]any{
+		".":   map[string]any{"a": []any{1}},
+		"a":   []any{1},
+		"a.0": 1,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+}
+
+func TestLeaves(t *testing.T) {
+	var got []string
+	for path := range mappath.Leaves(testIterData) {
+		if path == "items.1.name" {
+			break
+		}
+		got = append(got, path)
+	}
+
+	want := []string{"items.0.name", "items.0.price"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := map[string]struct {
+		key    string
+		result []string
+	}{
+		"map node": {
+			key:    "metadata",
+			result: []string{"password", "user"},
+		},
+		"slice node": {
+			key:    "items",
+			result: []string{"0", "1", "2"},
+		},
+		"scalar node": {
+			key:    "message",
+			result: nil,
+		},
+		"no such node": {
+			key:    "foo.bar",
+			result: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := slices.Collect(mappath.Keys(testIterData, test.key))
+			if !reflect.DeepEqual(got, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, got)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		result  map[string]any
+	}{
+		"no wildcards": {
+			pattern: "metadata.user.name",
+			result: map[string]any{
+				"metadata.user.name": "John Doe",
+			},
+		},
+		"single level wildcard": {
+			pattern: "items.*.price",
+			result: map[string]any{
+				"items.0.price": 10,
+				"items.2.price": 30,
+			},
+		},
+		"any level wildcard": {
+			pattern: "**.password",
+			result: map[string]any{
+				"metadata.password":      "secret too",
+				"metadata.user.password": "secret",
+			},
+		},
+		"negative index": {
+			pattern: "items.-1.*",
+			result: map[string]any{
+				"items.-1.price": 30,
+			},
+		},
+		"root": {
+			pattern: ".",
+			result: map[string]any{
+				".": testIterData,
+			},
+		},
+		"no matches": {
+			pattern: "items.*.foo",
+			result:  map[string]any{},
+		},
+		"invalid pattern": {
+			pattern: ".items",
+			result:  map[string]any{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := maps.Collect(mappath.Match(testIterData, test.pattern))
+			if !reflect.DeepEqual(got, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, got)
+			}
+		})
+	}
+}
+
+func TestMatchUnique(t *testing.T) {
+	data := map[string]any{"a": map[string]any{"b": 1}}
+
+	for _, pattern := range []string{"**.**", "**.*.**", "**.b.**"} {
+		var paths []string
+		for path := range mappath.Match(data, pattern) {
+			paths = append(paths, path)
+		}
+
+		if len(paths) != len(slices.Compact(slices.Sorted(slices.Values(paths)))) {
+			t.Errorf("unexpected result for %v - paths must be unique, got: %v", pattern, paths)
+		}
+	}
+}
@@ -0,0 +1,560 @@
+package mappath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type InvalidJSONError struct {
+	Offset int
+	Reason string
+}
+
+func (e *InvalidJSONError) Error() string { return fmt.Sprintf("offset %v: %v", e.Offset, e.Reason) }
+
+// GetJSON scans raw JSON to the value by specified key and decodes only that value, like json.Unmarshal into any.
+//
+// Only the parts of the document that lie on the way to the value are scanned, so the rest of it is not validated.
+func GetJSON(raw []byte, key string) (any, error) {
+	val, err := GetJSONRaw(raw, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	if err := json.Unmarshal(val, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetJSONRaw scans raw JSON to the value by specified key and returns it as a sub-slice of raw, without decoding.
+func GetJSONRaw(raw []byte, key string) (json.RawMessage, error) {
+	m, err := locateJSON(raw, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw[m.valStart:m.valEnd], nil
+}
+
+// jsonMember is a position of an object member or an array element in raw JSON.
+type jsonMember struct {
+	key      []byte // raw key without quotes, nil for array elements
+	keyStart int    // equal to valStart for array elements
+	valStart int
+	valEnd   int
+}
+
+func locateJSON(raw []byte, key string) (jsonMember, error) {
+	if len(key) == 0 {
+		return jsonMember{}, &InvalidPathError{
+			Path:   key,
+			Reason: "key length cannot be zero",
+		}
+	}
+
+	start := skipSpace(raw, 0)
+	if key == "." {
+		end, err := skipValue(raw, start)
+		if err != nil {
+			return jsonMember{}, err
+		}
+		return jsonMember{keyStart: start, valStart: start, valEnd: end}, nil
+	}
+
+	if key[0] == '.' {
+		return jsonMember{}, &InvalidPathError{
+			Path:   key,
+			Reason: "key cannot start from dot",
+		}
+	}
+
+	var (
+		m   = jsonMember{keyStart: start, valStart: start}
+		err error
+	)
+	for {
+		dotIndex := strings.IndexRune(key, '.')
+		if dotIndex < 0 { // no nested keys
+			return searchInJSON(raw, m.valStart, key)
+		}
+
+		m, err = searchInJSON(raw, m.valStart, key[:dotIndex])
+		if err != nil {
+			return jsonMember{}, err
+		}
+
+		key = key[dotIndex+1:]
+	}
+}
+
+func searchInJSON(raw []byte, pos int, key string) (jsonMember, error) {
+	var (
+		found jsonMember
+		ok    bool
+		err   error
+	)
+
+	if pos >= len(raw) {
+		return jsonMember{}, jsonError(raw, pos, "value")
+	}
+
+	switch raw[pos] {
+	case '{':
+		_, err = scanMembers(raw, pos, func(m jsonMember) bool {
+			if jsonKeyEqual(m.key, key) {
+				found, ok = m, true
+				return false
+			}
+			return true
+		})
+	case '[':
+		i, convErr := strconv.Atoi(key)
+		if convErr != nil {
+			return jsonMember{}, &InvalidPathError{
+				Path:   key,
+				Reason: "target node is an array, but provided key cannot be converted into int",
+			}
+		}
+
+		if i < 0 {
+			n := 0
+			if _, err := scanMembers(raw, pos, func(jsonMember) bool { n++; return true }); err != nil {
+				return jsonMember{}, err
+			}
+
+			if i += n; i < 0 {
+				return jsonMember{}, &InvalidPathError{
+					Path:   key,
+					Reason: "node is an array, but provided negative index is out of range",
+				}
+			}
+		}
+
+		_, err = scanMembers(raw, pos, func(m jsonMember) bool {
+			if i == 0 {
+				found, ok = m, true
+				return false
+			}
+			i--
+			return true
+		})
+	default:
+		return jsonMember{}, &InvalidPathError{
+			Path:   key,
+			Reason: "node must be an object or an array",
+		}
+	}
+
+	if err != nil {
+		return jsonMember{}, err
+	}
+
+	if !ok {
+		return jsonMember{}, &NotFoundError{
+			Path:   key,
+			Reason: "no such key in JSON",
+		}
+	}
+
+	return found, nil
+}
+
+// scanMembers calls fn for every member of an object or an array starting at pos, until fn returns false.
+// It returns position right after the container, or after the last visited member if scan was stopped.
+func scanMembers(raw []byte, pos int, fn func(m jsonMember) bool) (int, error) {
+	isObject := raw[pos] == '{'
+	closing := byte(']')
+	if isObject {
+		closing = '}'
+	}
+
+	pos = skipSpace(raw, pos+1)
+	if pos < len(raw) && raw[pos] == closing {
+		return pos + 1, nil
+	}
+
+	for {
+		m := jsonMember{keyStart: pos}
+		if isObject {
+			if pos >= len(raw) || raw[pos] != '"' {
+				return 0, jsonError(raw, pos, "object key")
+			}
+
+			keyEnd, err := skipString(raw, pos)
+			if err != nil {
+				return 0, err
+			}
+			m.key = raw[pos+1 : keyEnd-1]
+
+			pos = skipSpace(raw, keyEnd)
+			if pos >= len(raw) || raw[pos] != ':' {
+				return 0, jsonError(raw, pos, "colon after object key")
+			}
+			pos = skipSpace(raw, pos+1)
+		}
+
+		end, err := skipValue(raw, pos)
+		if err != nil {
+			return 0, err
+		}
+		m.valStart, m.valEnd = pos, end
+
+		if !fn(m) {
+			return end, nil
+		}
+
+		pos = skipSpace(raw, end)
+		if pos >= len(raw) {
+			return 0, jsonError(raw, pos, "comma or closing bracket")
+		}
+
+		switch raw[pos] {
+		case ',':
+			pos = skipSpace(raw, pos+1)
+		case closing:
+			return pos + 1, nil
+		default:
+			return 0, jsonError(raw, pos, "comma or closing bracket")
+		}
+	}
+}
+
+func skipValue(raw []byte, pos int) (int, error) {
+	if pos >= len(raw) {
+		return 0, jsonError(raw, pos, "value")
+	}
+
+	switch c := raw[pos]; {
+	case c == '{' || c == '[':
+		return scanMembers(raw, pos, func(jsonMember) bool { return true })
+	case c == '"':
+		return skipString(raw, pos)
+	case c == 't':
+		return skipLiteral(raw, pos, "true")
+	case c == 'f':
+		return skipLiteral(raw, pos, "false")
+	case c == 'n':
+		return skipLiteral(raw, pos, "null")
+	case c == '-' || (c >= '0' && c <= '9'):
+		end := pos + 1
+		for end < len(raw) && strings.IndexByte("0123456789+-.eE", raw[end]) >= 0 {
+			end++
+		}
+		return end, nil
+	default:
+		return 0, jsonError(raw, pos, "value")
+	}
+}
+
+func skipString(raw []byte, pos int) (int, error) {
+	for i := pos + 1; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c == '\\':
+			i++
+		case c == '"':
+			return i + 1, nil
+		case c < 0x20:
+			return 0, jsonError(raw, i, "string character")
+		}
+	}
+
+	return 0, jsonError(raw, len(raw), "end of string")
+}
+
+func skipLiteral(raw []byte, pos int, lit string) (int, error) {
+	if !bytes.HasPrefix(raw[pos:], []byte(lit)) {
+		return 0, jsonError(raw, pos, lit)
+	}
+	return pos + len(lit), nil
+}
+
+func skipSpace(raw []byte, pos int) int {
+	for pos < len(raw) {
+		switch raw[pos] {
+		case ' ', '\t', '\n', '\r':
+			pos++
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// jsonKeyEqual compares raw object key with a path segment, unescaping the key only if needed.
+func jsonKeyEqual(raw []byte, key string) bool {
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return string(raw) == key
+	}
+
+	var k string
+	if err := json.Unmarshal(append(append([]byte{'"'}, raw...), '"'), &k); err != nil {
+		return false
+	}
+	return k == key
+}
+
+func jsonError(raw []byte, pos int, expected string) error {
+	if pos >= len(raw) {
+		return &InvalidJSONError{
+			Offset: pos,
+			Reason: fmt.Sprintf("unexpected end of JSON input, expecting %v", expected),
+		}
+	}
+
+	return &InvalidJSONError{
+		Offset: pos,
+		Reason: fmt.Sprintf("invalid character %q, expecting %v", raw[pos], expected),
+	}
+}
+
+// SetJSON puts a passed value on a specified path in raw JSON and returns the updated document.
+// The value is encoded with json.Marshal and spliced into the document, formatting and order of untouched members are preserved.
+//
+// Missing nodes are created in the same way as Put does, new members are appended to the end of their objects.
+func SetJSON(raw []byte, key string, val any) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key length cannot be zero",
+		}
+	}
+
+	start := skipSpace(raw, 0)
+	if key == "." {
+		end, err := skipValue(raw, start)
+		if err != nil {
+			return nil, err
+		}
+		return mergeJSON(raw, start, end, val)
+	}
+
+	if key[0] == '.' {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key cannot start from dot",
+		}
+	}
+
+	if err := DefaultLimits.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	return setInJSON(raw, start, strings.Split(key, "."), val)
+}
+
+// DeleteJSON deletes a value on a specified path in raw JSON and returns the updated document.
+// Formatting and order of untouched members are preserved.
+func DeleteJSON(raw []byte, key string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key length cannot be zero",
+		}
+	}
+
+	if key == "." {
+		return []byte("null"), nil
+	}
+
+	if key[0] == '.' {
+		return nil, &InvalidPathError{
+			Path:   key,
+			Reason: "key cannot start from dot",
+		}
+	}
+
+	if err := DefaultLimits.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	pos := skipSpace(raw, 0)
+	segs := strings.Split(key, ".")
+	for _, seg := range segs[:len(segs)-1] {
+		m, err := searchInJSON(raw, pos, seg)
+		if err != nil {
+			return nil, err
+		}
+		pos = m.valStart
+	}
+
+	m, err := searchInJSON(raw, pos, segs[len(segs)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	members, _, err := collectMembers(raw, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(members, func(e jsonMember) bool { return e.keyStart == m.keyStart })
+	switch {
+	case i < len(members)-1: // member with the following comma
+		return spliceJSON(raw, members[i].keyStart, members[i+1].keyStart, nil), nil
+	case i > 0: // last member with the preceding comma
+		return spliceJSON(raw, members[i-1].valEnd, members[i].valEnd, nil), nil
+	default: // the only member
+		return spliceJSON(raw, members[i].keyStart, members[i].valEnd, nil), nil
+	}
+}
+
+func setInJSON(raw []byte, pos int, segs []string, val any) ([]byte, error) {
+	for i, seg := range segs {
+		if pos >= len(raw) {
+			return nil, jsonError(raw, pos, "value")
+		}
+
+		switch raw[pos] {
+		case '{', '[':
+		case 'n': // null node is replaced, like nil node in Put
+			end, err := skipValue(raw, pos)
+			if err != nil {
+				return nil, err
+			}
+			return replaceJSON(raw, pos, end, segs[i:], val)
+		default:
+			return nil, &InvalidPathError{
+				Path:   seg,
+				Reason: "node must be an object or an array",
+			}
+		}
+
+		m, err := searchInJSON(raw, pos, seg)
+		var notFoundError *NotFoundError
+		switch {
+		case err == nil:
+			if i == len(segs)-1 {
+				return replaceJSON(raw, m.valStart, m.valEnd, nil, val)
+			}
+			pos = m.valStart
+			continue
+		case !errors.As(err, &notFoundError):
+			return nil, err
+		}
+
+		// no such member, so it is appended with all remaining nodes
+		members, closing, err := collectMembers(raw, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := buildJSON(segs[i+1:], val)
+		if err != nil {
+			return nil, err
+		}
+
+		var ins []byte
+		if raw[pos] == '{' {
+			k, err := json.Marshal(seg)
+			if err != nil {
+				return nil, err
+			}
+			ins = append(append(k, ':'), v...)
+		} else {
+			n, _ := strconv.Atoi(seg) // already checked by searchInJSON
+			if err := DefaultLimits.checkGrowth(strings.Join(segs[:i+1], "."), n+1-len(members)); err != nil {
+				return nil, err
+			}
+
+			for j := len(members); j < n; j++ {
+				ins = append(ins, "null,"...)
+			}
+			ins = append(ins, v...)
+		}
+
+		if len(members) == 0 {
+			return spliceJSON(raw, closing, closing, ins), nil
+		}
+
+		at := members[len(members)-1].valEnd
+		return spliceJSON(raw, at, at, append([]byte{','}, ins...)), nil
+	}
+
+	return raw, nil
+}
+
+// mergeJSON merges a value into the document root in the same way as Put with dot key does.
+func mergeJSON(raw []byte, start, end int, val any) ([]byte, error) {
+	if raw[start] == 'n' {
+		return replaceJSON(raw, start, end, nil, val)
+	}
+
+	if vl, ok := val.(map[string]any); ok && raw[start] == '{' {
+		var err error
+		for _, k := range slices.Sorted(maps.Keys(vl)) {
+			if raw, err = setInJSON(raw, start, []string{k}, vl[k]); err != nil {
+				return nil, err
+			}
+		}
+		return raw, nil
+	}
+
+	if vl, ok := val.([]any); ok && raw[start] == '[' {
+		members, _, err := collectMembers(raw, start)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, v := range vl {
+			if raw, err = setInJSON(raw, start, []string{strconv.Itoa(len(members) + i)}, v); err != nil {
+				return nil, err
+			}
+		}
+		return raw, nil
+	}
+
+	return nil, &InvalidPathError{
+		Path:   ".",
+		Reason: "dot merge error: both root node and value must be an object or an array",
+	}
+}
+
+// replaceJSON replaces raw[start:end] with the value built from remaining segments.
+func replaceJSON(raw []byte, start, end int, segs []string, val any) ([]byte, error) {
+	v, err := buildJSON(segs, val)
+	if err != nil {
+		return nil, err
+	}
+	return spliceJSON(raw, start, end, v), nil
+}
+
+// buildJSON encodes a value nested into new nodes by remaining segments.
+func buildJSON(segs []string, val any) ([]byte, error) {
+	if len(segs) > 0 {
+		var err error
+		if val, err = putInKey(nil, strings.Join(segs, "."), val); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(val)
+}
+
+// collectMembers returns all members of an object or an array and position of its closing bracket.
+func collectMembers(raw []byte, pos int) ([]jsonMember, int, error) {
+	var members []jsonMember
+	end, err := scanMembers(raw, pos, func(m jsonMember) bool {
+		members = append(members, m)
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return members, end - 1, nil
+}
+
+// spliceJSON returns a copy of raw with raw[start:end] replaced by ins.
+func spliceJSON(raw []byte, start, end int, ins []byte) []byte {
+	out := make([]byte, 0, len(raw)-(end-start)+len(ins))
+	out = append(out, raw[:start]...)
+	out = append(out, ins...)
+	return append(out, raw[end:]...)
+}
@@ -0,0 +1,361 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+const testJSON = `
+{
+    "message": "user login",
+    "level": null,
+    "metadata": {
+        "user": {
+            "name": "John \"JD\" Doe",
+            "roles": [ "employee", "manager" ],
+            "age": 42,
+            "active": true
+        }
+    },
+    "items": [ { "price": 10.5 }, { "price": -3e2 } ]
+}
+`
+
+func TestGetJSON(t *testing.T) {
+	tests := map[string]struct {
+		raw    string
+		key    string
+		result any
+		err    error
+	}{
+		"root, ok value": {
+			raw:    `[1, 2]`,
+			key:    ".",
+			result: []any{1.0, 2.0},
+			err:    nil,
+		},
+		"nested string with escapes, ok value": {
+			raw:    testJSON,
+			key:    "metadata.user.name",
+			result: `John "JD" Doe`,
+			err:    nil,
+		},
+		"array element, ok value": {
+			raw:    testJSON,
+			key:    "metadata.user.roles.1",
+			result: "manager",
+			err:    nil,
+		},
+		"negative index, ok value": {
+			raw:    testJSON,
+			key:    "items.-1.price",
+			result: -300.0,
+			err:    nil,
+		},
+		"whole object, ok value": {
+			raw:    testJSON,
+			key:    "items.0",
+			result: map[string]any{"price": 10.5},
+			err:    nil,
+		},
+		"null value, ok value": {
+			raw:    testJSON,
+			key:    "level",
+			result: nil,
+			err:    nil,
+		},
+		"bool value, ok value": {
+			raw:    testJSON,
+			key:    "metadata.user.active",
+			result: true,
+			err:    nil,
+		},
+		"escaped key, ok value": {
+			raw:    `{"abc": 1}`,
+			key:    "abc",
+			result: 1.0,
+			err:    nil,
+		},
+		"broken tail is not scanned, ok value": {
+			raw:    `{"foo": "bar", "fizz": [}`,
+			key:    "foo",
+			result: "bar",
+			err:    nil,
+		},
+		"no such key, not found": {
+			raw:    testJSON,
+			key:    "metadata.user.login",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"index out of range, not found": {
+			raw:    testJSON,
+			key:    "items.2",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"negative index out of range, invalid path": {
+			raw:    testJSON,
+			key:    "items.-3",
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+		"not an int for array, invalid path": {
+			raw:    testJSON,
+			key:    "items.foo",
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+		"through scalar, invalid path": {
+			raw:    testJSON,
+			key:    "message.foo",
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+		"broken document, invalid json": {
+			raw:    `{"foo" "bar"}`,
+			key:    "foo",
+			result: nil,
+			err:    &mappath.InvalidJSONError{},
+		},
+		"empty document, invalid json": {
+			raw:    ``,
+			key:    "foo",
+			result: nil,
+			err:    &mappath.InvalidJSONError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.GetJSON([]byte(test.raw), test.key)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestGetJSONRaw(t *testing.T) {
+	val, err := mappath.GetJSONRaw([]byte(testJSON), "metadata.user.roles")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if want := `[ "employee", "manager" ]`; string(val) != want {
+		t.Errorf("unexpected result - want: %v, got: %v", want, string(val))
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	tests := map[string]struct {
+		raw    string
+		key    string
+		val    any
+		result string
+		err    error
+	}{
+		"replace value, formatting kept": {
+			raw:    "{\n  \"b\": 1,\n  \"a\": [ 1, 2 ]\n}",
+			key:    "b",
+			val:    "new",
+			result: "{\n  \"b\": \"new\",\n  \"a\": [ 1, 2 ]\n}",
+			err:    nil,
+		},
+		"add member to the end": {
+			raw:    `{"b": 1, "a": {"x": true} }`,
+			key:    "a.y",
+			val:    map[string]any{"z": nil},
+			result: `{"b": 1, "a": {"x": true,"y":{"z":null}} }`,
+			err:    nil,
+		},
+		"add member to empty object": {
+			raw:    `{"a": { }}`,
+			key:    "a.b",
+			val:    1,
+			result: `{"a": { "b":1}}`,
+			err:    nil,
+		},
+		"add nested members": {
+			raw:    `{"a": 1}`,
+			key:    "b.c.1",
+			val:    "x",
+			result: `{"a": 1,"b":{"c":[null,"x"]}}`,
+			err:    nil,
+		},
+		"replace null node": {
+			raw:    `{"a": null}`,
+			key:    "a.b",
+			val:    1,
+			result: `{"a": {"b":1}}`,
+			err:    nil,
+		},
+		"grow array": {
+			raw:    `{"a": [1]}`,
+			key:    "a.3",
+			val:    4,
+			result: `{"a": [1,null,null,4]}`,
+			err:    nil,
+		},
+		"set in empty array": {
+			raw:    `[]`,
+			key:    "0",
+			val:    "x",
+			result: `["x"]`,
+			err:    nil,
+		},
+		"negative index": {
+			raw:    `[1, 2, 3]`,
+			key:    "-1",
+			val:    "x",
+			result: `[1, 2, "x"]`,
+			err:    nil,
+		},
+		"dot merge objects": {
+			raw:    `{"a": 1}`,
+			key:    ".",
+			val:    map[string]any{"c": 3, "a": 2},
+			result: `{"a": 2,"c":3}`,
+			err:    nil,
+		},
+		"dot merge arrays": {
+			raw:    `[1]`,
+			key:    ".",
+			val:    []any{2, 3},
+			result: `[1,2,3]`,
+			err:    nil,
+		},
+		"through scalar, invalid path": {
+			raw:    `{"a": "b"}`,
+			key:    "a.b",
+			val:    1,
+			result: "",
+			err:    &mappath.InvalidPathError{},
+		},
+		"negative index out of range, invalid path": {
+			raw:    `[1]`,
+			key:    "-2",
+			val:    1,
+			result: "",
+			err:    &mappath.InvalidPathError{},
+		},
+		"broken document, invalid json": {
+			raw:    `{"a": [1, }`,
+			key:    "a.5",
+			val:    1,
+			result: "",
+			err:    &mappath.InvalidJSONError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.SetJSON([]byte(test.raw), test.key, test.val)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if string(val) != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, string(val))
+			}
+		})
+	}
+}
+
+func TestDeleteJSON(t *testing.T) {
+	tests := map[string]struct {
+		raw    string
+		key    string
+		result string
+		err    error
+	}{
+		"first member": {
+			raw:    `{"a": 1, "b": 2, "c": 3}`,
+			key:    "a",
+			result: `{"b": 2, "c": 3}`,
+			err:    nil,
+		},
+		"middle member": {
+			raw:    "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}",
+			key:    "b",
+			result: "{\n  \"a\": 1,\n  \"c\": 3\n}",
+			err:    nil,
+		},
+		"last member": {
+			raw:    `{"a": 1, "b": 2}`,
+			key:    "b",
+			result: `{"a": 1}`,
+			err:    nil,
+		},
+		"the only member": {
+			raw:    `{"a": { "b": [1] }}`,
+			key:    "a.b",
+			result: `{"a": {  }}`,
+			err:    nil,
+		},
+		"array element by negative index": {
+			raw:    `{"a": [1, 2, 3]}`,
+			key:    "a.-2",
+			result: `{"a": [1, 3]}`,
+			err:    nil,
+		},
+		"root": {
+			raw:    `{"a": 1}`,
+			key:    ".",
+			result: `null`,
+			err:    nil,
+		},
+		"no such key, not found": {
+			raw:    `{"a": 1}`,
+			key:    "b",
+			result: "",
+			err:    &mappath.NotFoundError{},
+		},
+		"through scalar, invalid path": {
+			raw:    `{"a": 1}`,
+			key:    "a.b",
+			result: "",
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.DeleteJSON([]byte(test.raw), test.key)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if string(val) != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, string(val))
+			}
+		})
+	}
+}
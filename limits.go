@@ -0,0 +1,211 @@
+package mappath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LimitExceededError is returned, if a key or produced data exceeds configured limits.
+type LimitExceededError struct {
+	Path   string
+	Reason string
+}
+
+func (e *LimitExceededError) Error() string { return fmt.Sprintf("%v: %v", e.Path, e.Reason) }
+
+// Limits restrict keys accepted by functions, that modify data, and data they produce,
+// so keys from untrusted input cannot exhaust memory. A zero field means no limit.
+//
+// Limits are checked before data is modified, so failed operations leave data as is.
+// Package functions apply DefaultLimits, methods of Limits apply their own limits to a single call.
+type Limits struct {
+	// MaxDepth is a maximum number of key segments.
+	MaxDepth int
+	// MaxKeyLength is a maximum key length in bytes.
+	MaxKeyLength int
+	// MaxIndexGrowth is a maximum number of elements, that a single put may add to a slice,
+	// including a new one. For example, putting by `items.9` into an empty slice adds 10 elements.
+	MaxIndexGrowth int
+	// MaxNodes is a maximum number of nodes in data after a put, where each container and each value is a node.
+	// Checking it requires counting nodes of the whole data on each put.
+	MaxNodes int
+}
+
+// DefaultLimits are applied by Put, Delete and functions built on them. There are no limits by default.
+// Changes are not synchronized, so set limits before use, e.g. in init.
+var DefaultLimits Limits
+
+// checkKey checks a key before it is split into segments.
+func (l Limits) checkKey(key string) error {
+	if err := l.checkLength(key, len(key)); err != nil {
+		return err
+	}
+
+	if l.MaxDepth > 0 {
+		return l.checkDepth(key, strings.Count(key, ".")+1)
+	}
+	return nil
+}
+
+// checkPath checks a key split into segments.
+func (l Limits) checkPath(path []string) error {
+	if l.MaxKeyLength > 0 {
+		length := len(path) - 1
+		for _, seg := range path {
+			length += len(seg)
+		}
+
+		if length > l.MaxKeyLength {
+			return l.checkLength(strings.Join(path, "."), length)
+		}
+	}
+
+	if l.MaxDepth > 0 && len(path) > l.MaxDepth {
+		return l.checkDepth(strings.Join(path, "."), len(path))
+	}
+	return nil
+}
+
+func (l Limits) checkLength(key string, length int) error {
+	if l.MaxKeyLength > 0 && length > l.MaxKeyLength {
+		return &LimitExceededError{
+			Path:   key[:min(len(key), l.MaxKeyLength)] + "...",
+			Reason: fmt.Sprintf("key length %v exceeds the limit of %v", length, l.MaxKeyLength),
+		}
+	}
+	return nil
+}
+
+func (l Limits) checkDepth(key string, depth int) error {
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return &LimitExceededError{
+			Path:   key,
+			Reason: fmt.Sprintf("key depth %v exceeds the limit of %v", depth, l.MaxDepth),
+		}
+	}
+	return nil
+}
+
+// checkPut estimates slices growth and number of nodes, that a put by path produces, without modifying data.
+func (l Limits) checkPut(p any, path []string, val any) error {
+	if l.MaxIndexGrowth <= 0 && l.MaxNodes <= 0 {
+		return nil
+	}
+
+	var added int
+	node, exists := p, p != nil
+	for i, seg := range path {
+		j, err := strconv.Atoi(seg)
+		isIndex := err == nil && j >= 0
+
+		var growth int
+		switch {
+		case !exists: // a new node will be created
+			added++
+			if isIndex {
+				growth = j + 1
+				added += j
+			}
+		case isIndex:
+			if n, ok := lenOf(node); ok && j >= n {
+				growth = j + 1 - n
+				added += j - n
+			}
+		}
+
+		if l.MaxIndexGrowth > 0 && growth > l.MaxIndexGrowth {
+			return l.checkGrowth(strings.Join(path[:i+1], "."), growth)
+		}
+
+		if exists {
+			node, err = searchInNode(node, seg)
+			exists = err == nil
+		}
+	}
+
+	if l.MaxNodes <= 0 {
+		return nil
+	}
+
+	if exists { // replaced value
+		added -= countNodes(node)
+	}
+
+	total := added + countNodes(val)
+	if p != nil {
+		total += countNodes(p)
+	}
+
+	return l.checkNodes(strings.Join(path, "."), total)
+}
+
+func (l Limits) checkGrowth(key string, growth int) error {
+	if l.MaxIndexGrowth > 0 && growth > l.MaxIndexGrowth {
+		return &LimitExceededError{
+			Path:   key,
+			Reason: fmt.Sprintf("slice growth by %v elements exceeds the limit of %v", growth, l.MaxIndexGrowth),
+		}
+	}
+	return nil
+}
+
+// checkMerge checks number of nodes after the dot merge of val into p.
+func (l Limits) checkMerge(p, val any) error {
+	if l.MaxNodes <= 0 {
+		return nil
+	}
+
+	if p == nil {
+		return l.checkNodes(".", countNodes(val))
+	}
+
+	total := countNodes(p) + countNodes(val) - 1
+	if _, ok := p.([]any); !ok {
+		for _, k := range childrenOf(val) {
+			if old, err := searchInNode(p, k); err == nil { // replaced value
+				total -= countNodes(old)
+			}
+		}
+	}
+
+	return l.checkNodes(".", total)
+}
+
+func (l Limits) checkNodes(key string, total int) error {
+	if l.MaxNodes > 0 && total > l.MaxNodes {
+		return &LimitExceededError{
+			Path:   key,
+			Reason: fmt.Sprintf("number of nodes %v exceeds the limit of %v", total, l.MaxNodes),
+		}
+	}
+	return nil
+}
+
+// countNodes counts the node and all its descendants using an explicit stack.
+func countNodes(p any) int {
+	var n int
+	stack := []any{p}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n++
+
+		switch t := node.(type) {
+		case nil, string, bool, float64, int:
+		case map[string]any:
+			for _, v := range t {
+				stack = append(stack, v)
+			}
+		case []any:
+			stack = append(stack, t...)
+		default:
+			for _, k := range childrenOf(node) {
+				if child, err := searchInNode(node, k); err == nil {
+					stack = append(stack, child)
+				}
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,361 @@
+package mappath_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestDeepKeys(t *testing.T) {
+	key := strings.Repeat("a.", 9999) + "b"
+
+	data, err := mappath.Put(nil, key, 1)
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if val, err := mappath.Get(data, key); err != nil || val != 1 {
+		t.Errorf("unexpected result - want: 1, got: %v, %v", val, err)
+	}
+
+	data, err = mappath.DeleteWithOpts(data, key, mappath.DeleteOpts{PruneEmpty: true})
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(data, map[string]any{}) {
+		t.Errorf("unexpected result - want: empty map, got: %v", data)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+	mappath.DefaultLimits = mappath.Limits{MaxDepth: 3}
+
+	tests := map[string]struct {
+		op  func() (any, error)
+		err error
+	}{
+		"put within limit": {
+			op: func() (any, error) { return mappath.Put(nil, "a.b.c", 1) },
+		},
+		"put": {
+			op:  func() (any, error) { return mappath.Put(nil, "a.b.c.d", 1) },
+			err: &mappath.LimitExceededError{},
+		},
+		"delete": {
+			op:  func() (any, error) { return mappath.Delete(map[string]any{}, "a.b.c.d") },
+			err: &mappath.LimitExceededError{},
+		},
+		"apply under remembered prefix": {
+			op: func() (any, error) {
+				return mappath.Apply(nil, mappath.PutOp("a.b.c", 1), mappath.PutOp("a.b.d.e", 1))
+			},
+			err: &mappath.LimitExceededError{},
+		},
+		"unflatten": {
+			op:  func() (any, error) { return mappath.Unflatten(map[string]any{"a.b.c.d": 1}) },
+			err: &mappath.LimitExceededError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.op()
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: %v, got: nil", reflect.TypeOf(test.err).String())
+				}
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	newData := func() any {
+		return map[string]any{
+			"a":     1,
+			"items": []any{1, 2},
+			"user":  &testUser{Roles: []string{"admin"}},
+		}
+	}
+
+	tests := map[string]struct {
+		limits mappath.Limits
+		key    string
+		val    any
+		err    error
+	}{
+		"huge index into new slice": {
+			limits: mappath.Limits{MaxIndexGrowth: 1000},
+			key:    "b.999999999",
+			err:    &mappath.LimitExceededError{},
+		},
+		"huge index into new node": {
+			limits: mappath.Limits{MaxIndexGrowth: 1000},
+			key:    "b.999999999.c",
+			err:    &mappath.LimitExceededError{},
+		},
+		"growth of existing slice": {
+			limits: mappath.Limits{MaxIndexGrowth: 3},
+			key:    "items.5",
+			err:    &mappath.LimitExceededError{},
+		},
+		"growth of existing slice within limit": {
+			limits: mappath.Limits{MaxIndexGrowth: 3},
+			key:    "items.4",
+		},
+		"growth of struct slice": {
+			limits: mappath.Limits{MaxIndexGrowth: 3},
+			key:    "user.roles.10",
+			val:    "manager",
+			err:    &mappath.LimitExceededError{},
+		},
+		"key length": {
+			limits: mappath.Limits{MaxKeyLength: 8},
+			key:    "metadata.user",
+			err:    &mappath.LimitExceededError{},
+		},
+		"key length within limit": {
+			limits: mappath.Limits{MaxKeyLength: 8},
+			key:    "metadata",
+		},
+		"nodes": {
+			limits: mappath.Limits{MaxNodes: 16},
+			key:    "b",
+			val:    []any{1, 2, 3},
+			err:    &mappath.LimitExceededError{},
+		},
+		"replaced nodes are not counted": {
+			limits: mappath.Limits{MaxNodes: 14},
+			key:    "items",
+			val:    []any{1, 2, 3},
+		},
+		"merge nodes": {
+			limits: mappath.Limits{MaxNodes: 16},
+			key:    ".",
+			val:    map[string]any{"b": []any{1, 2, 3}},
+			err:    &mappath.LimitExceededError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+			mappath.DefaultLimits = test.limits
+
+			p := newData()
+			_, err := mappath.Put(p, test.key, test.val)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err), err)
+				}
+
+				if !reflect.DeepEqual(p, newData()) {
+					t.Errorf("data is modified on error: %v", p)
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: %v, got: nil", reflect.TypeOf(test.err).String())
+				}
+			}
+		})
+	}
+}
+
+func TestMaxIndexGrowthIntoNil(t *testing.T) {
+	defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+	mappath.DefaultLimits = mappath.Limits{MaxIndexGrowth: 1000}
+
+	var limitError *mappath.LimitExceededError
+	if _, err := mappath.Put(nil, "999999999", 1); !errors.As(err, &limitError) {
+		t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+	}
+}
+
+func TestMaxNodesEstimate(t *testing.T) {
+	defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+
+	tests := map[string]struct {
+		p   func() any
+		key string
+		val any
+	}{
+		"into nil":            {p: func() any { return nil }, key: "a.2.b", val: 1},
+		"new key":             {p: func() any { return map[string]any{"a": 1} }, key: "b.c", val: []any{1, 2}},
+		"replace":             {p: func() any { return map[string]any{"a": []any{1, 2}} }, key: "a", val: 1},
+		"replace nil":         {p: func() any { return map[string]any{"a": nil} }, key: "a", val: map[string]any{"b": 1}},
+		"slice growth":        {p: func() any { return []any{1} }, key: "3", val: 1},
+		"slice growth nested": {p: func() any { return map[string]any{"a": []any{}} }, key: "a.2.b.1", val: 1},
+		"negative index":      {p: func() any { return []any{1, map[string]any{}} }, key: "-1.a", val: 1},
+		"ordered map":         {p: func() any { return mappath.NewOrderedMap() }, key: "a.b", val: 1},
+		"struct":              {p: func() any { return &testUser{Roles: []string{"a"}} }, key: "roles.2", val: "b"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mappath.DefaultLimits = mappath.Limits{}
+			result, err := mappath.Put(test.p(), test.key, test.val)
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			var nodes int
+			for range mappath.All(result) {
+				nodes++
+			}
+
+			mappath.DefaultLimits = mappath.Limits{MaxNodes: nodes}
+			if _, err := mappath.Put(test.p(), test.key, test.val); err != nil {
+				t.Errorf("unexpected error with limit %v - want: nil, got: %v", nodes, err)
+			}
+
+			mappath.DefaultLimits = mappath.Limits{MaxNodes: nodes - 1}
+			if _, err := mappath.Put(test.p(), test.key, test.val); err == nil {
+				t.Errorf("unexpected error with limit %v - want: LimitExceededError, got: nil", nodes-1)
+			}
+		})
+	}
+}
+
+func TestLimitsMethods(t *testing.T) {
+	l := mappath.Limits{MaxNodes: 4, MaxIndexGrowth: 2}
+
+	tests := map[string]func(p any) (any, error){
+		"put": func(p any) (any, error) { return l.Put(p, "tags.3", "d") },
+		"put if absent": func(p any) (any, error) {
+			return l.PutIfAbsent(p, "items.5", 1)
+		},
+		"append to": func(p any) (any, error) { return l.AppendTo(p, "tags", "b", "c", "d") },
+		"apply": func(p any) (any, error) {
+			return l.Apply(p, mappath.PutOp("tags.1", "b"), mappath.PutOp("tags.9", "e"))
+		},
+	}
+
+	for name, op := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := op(map[string]any{"tags": []any{"a"}})
+
+			var limitError *mappath.LimitExceededError
+			if !errors.As(err, &limitError) {
+				t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+			}
+		})
+	}
+
+	if _, err := mappath.Put(map[string]any{}, "tags.3", "d"); err != nil {
+		t.Errorf("unexpected error - want: nil, got: %v", err)
+	}
+}
+
+func TestContainerLimits(t *testing.T) {
+	data := &mappath.Container{Data: map[string]any{"tags": []any{"a"}}}
+	c := data.WithLimits(mappath.Limits{MaxNodes: 4, MaxIndexGrowth: 2})
+
+	if err := c.AppendTo("tags", "b"); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{"tags": []any{"a", "b"}}
+	if !reflect.DeepEqual(data.Data, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, data.Data)
+	}
+
+	var limitError *mappath.LimitExceededError
+	if err := c.AppendTo("tags", "c"); !errors.As(err, &limitError) {
+		t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+	}
+
+	if err := c.Put("tags.3", "d"); !errors.As(err, &limitError) {
+		t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+	}
+
+	if err := c.Apply(mappath.DeleteOp("tags.0"), mappath.PutOp("tags.4", "e")); !errors.As(err, &limitError) {
+		t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+	}
+
+	cc := c.Clone()
+	if err := cc.PutIfAbsent("x.y", 1); !errors.As(err, &limitError) {
+		t.Errorf("unexpected error - want: LimitExceededError, got: %v", err)
+	}
+
+	if cc.Container() == data || !reflect.DeepEqual(cc.Container().Data, data.Data) {
+		t.Errorf("unexpected result - want: cloned %v, got: %v", data.Data, cc.Container().Data)
+	}
+}
+
+func TestDeleteLimits(t *testing.T) {
+	defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+	mappath.DefaultLimits = mappath.Limits{MaxIndexGrowth: 10}
+
+	tests := map[string]struct {
+		p  any
+		op func(p any) (any, error)
+	}{
+		"delete from nil": {
+			op: func(p any) (any, error) { return mappath.Delete(p, "99999999999") },
+		},
+		"delete from nil child": {
+			p:  map[string]any{"a": nil},
+			op: func(p any) (any, error) { return mappath.Delete(p, "a.3000000") },
+		},
+		"delete with opts": {
+			p: map[string]any{"a": nil},
+			op: func(p any) (any, error) {
+				return mappath.DeleteWithOpts(p, "a.3000000", mappath.DeleteOpts{PruneEmpty: true, StableIndexes: true})
+			},
+		},
+		"container delete": {
+			p: map[string]any{"a": nil},
+			op: func(p any) (any, error) {
+				c := &mappath.Container{Data: p}
+				err := c.WithLimits(mappath.Limits{MaxIndexGrowth: 10}).Delete("a.3000000")
+				return c.Data, err
+			},
+		},
+		"delete op": {
+			p:  map[string]any{"a": nil},
+			op: func(p any) (any, error) { return mappath.Apply(p, mappath.DeleteOp("a.3000000")) },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.op(test.p)
+
+			var notFoundError *mappath.NotFoundError
+			if !errors.As(err, &notFoundError) {
+				t.Errorf("unexpected error - want: NotFoundError, got: %v", err)
+			}
+
+			if test.p != nil && !reflect.DeepEqual(test.p, map[string]any{"a": nil}) {
+				t.Errorf("data is modified on error: %v", test.p)
+			}
+		})
+	}
+}
+
+func TestSetJSONLimits(t *testing.T) {
+	defer func(l mappath.Limits) { mappath.DefaultLimits = l }(mappath.DefaultLimits)
+	mappath.DefaultLimits = mappath.Limits{MaxIndexGrowth: 10, MaxKeyLength: 16}
+
+	var limitError *mappath.LimitExceededError
+	for _, key := range []string{"items.100", "meta.999999999", "metadata.user.login"} {
+		if _, err := mappath.SetJSON([]byte(`{"items": [1, 2]}`), key, 1); !errors.As(err, &limitError) {
+			t.Errorf("unexpected error for %v - want: LimitExceededError, got: %v", key, err)
+		}
+	}
+
+	if _, err := mappath.SetJSON([]byte(`{"items": [1, 2]}`), "items.5", 1); err != nil {
+		t.Errorf("unexpected error - want: nil, got: %v", err)
+	}
+}
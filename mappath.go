@@ -1,7 +1,6 @@
 package mappath
 
 import (
-	"errors"
 	"fmt"
 	"slices"
 	"strconv"
@@ -23,6 +22,9 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string { return fmt.Sprintf("%v: %v", e.Path, e.Reason) }
 
 // Get a value by specified key from provided map[string]any or []any.
+//
+// Structs and pointers to structs are traversed too, segments are resolved by json tag
+// with fallback to field name.
 func Get(p any, key string) (any, error) {
 	if len(key) == 0 {
 		return nil, &InvalidPathError{
@@ -70,7 +72,14 @@ func Get(p any, key string) (any, error) {
 }
 
 // Put a passed value on a specified path in the provided map[string]any or []any and get the updated object.
+//
+// Struct fields are updated in place when reached through a pointer, otherwise an updated copy is stored.
 func Put(p any, key string, val any) (any, error) {
+	return DefaultLimits.Put(p, key, val)
+}
+
+// Put is the same as package Put, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Put(p any, key string, val any) (any, error) {
 	if len(key) == 0 {
 		return nil, &InvalidPathError{
 			Path:   key,
@@ -79,6 +88,10 @@ func Put(p any, key string, val any) (any, error) {
 	}
 
 	if key == "." {
+		if err := l.checkMerge(p, val); err != nil {
+			return nil, err
+		}
+
 		if p == nil {
 			return val, nil
 		}
@@ -98,9 +111,15 @@ func Put(p any, key string, val any) (any, error) {
 			}
 		}
 
+		if pl, ok := p.(Node); ok {
+			if n, ok := mergeIntoNode(pl, val); ok {
+				return n, nil
+			}
+		}
+
 		return nil, &InvalidPathError{
 			Path:   key,
-			Reason: "dot merge error: both root node and value must be map[string]any or []any",
+			Reason: "dot merge error: both root node and value must be map[string]any, []any or Node",
 		}
 	}
 
@@ -111,11 +130,39 @@ func Put(p any, key string, val any) (any, error) {
 		}
 	}
 
-	return putInKey(p, key, val)
+	if err := l.checkKey(key); err != nil { // before the key is split
+		return nil, err
+	}
+
+	return l.putInPath(p, strings.Split(key, "."), val)
 }
 
 // Delete a value on a specified path in the provided map[string]any or []any and get the updated object.
 func Delete(p any, key string) (any, error) {
+	return DeleteWithOpts(p, key, DeleteOpts{})
+}
+
+// Delete is the same as package Delete, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Delete(p any, key string) (any, error) {
+	return l.DeleteWithOpts(p, key, DeleteOpts{})
+}
+
+type DeleteOpts struct {
+	// PruneEmpty removes maps, slices and Nodes left empty up the chain after deletion.
+	// The root node is never removed.
+	PruneEmpty bool
+	// StableIndexes sets deleted []any elements to nil instead of removing them,
+	// so indexes of following elements stay valid.
+	StableIndexes bool
+}
+
+// DeleteWithOpts is the same as Delete, but deletion is configured by provided options.
+func DeleteWithOpts(p any, key string, opts DeleteOpts) (any, error) {
+	return DefaultLimits.DeleteWithOpts(p, key, opts)
+}
+
+// DeleteWithOpts is the same as package DeleteWithOpts, but passed limits are applied instead of DefaultLimits.
+func (l Limits) DeleteWithOpts(p any, key string, opts DeleteOpts) (any, error) {
 	if len(key) == 0 {
 		return nil, &InvalidPathError{
 			Path:   key,
@@ -134,12 +181,69 @@ func Delete(p any, key string) (any, error) {
 		}
 	}
 
-	return deleteFromKey(p, key)
+	return l.deleteFromKey(p, key, opts)
+}
+
+// DeleteAll deletes values by all specified keys and gets the updated object.
+//
+// All keys are resolved against the passed data before deletion and applied starting from the greatest indexes,
+// so deleting `items.1` and `items.3` removes exactly these elements, regardless of keys order.
+// If any key does not exist, nothing is deleted. Keys nested into other deleted keys are skipped.
+// A dot key deletes the whole data, like Delete does, after other keys are checked.
+func DeleteAll(p any, keys ...string) (any, error) {
+	return DefaultLimits.DeleteAll(p, keys...)
 }
 
-// Clone passed map[string]any or []any.
+// DeleteAll is the same as package DeleteAll, but passed limits are applied instead of DefaultLimits.
+func (l Limits) DeleteAll(p any, keys ...string) (any, error) {
+	var root bool
+	paths := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "." {
+			root = true
+			continue
+		}
+
+		if len(key) == 0 {
+			return nil, &InvalidPathError{
+				Path:   key,
+				Reason: "key length cannot be zero",
+			}
+		}
+
+		if key[0] == '.' {
+			return nil, &InvalidPathError{
+				Path:   key,
+				Reason: "key cannot start from dot",
+			}
+		}
+
+		if err := l.checkKey(key); err != nil {
+			return nil, err
+		}
+
+		path, err := resolvePath(p, key)
+		if err != nil {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: fmt.Sprintf("no such key: %v", err),
+			}
+		}
+		paths = append(paths, path)
+	}
+
+	if root { // deletes everything, like Delete by dot does, but only if all other keys exist
+		return nil, nil
+	}
+
+	return l.deletePaths(p, paths)
+}
+
+// Clone passed map[string]any, []any or NodeCloner.
 func Clone(p any) any {
 	switch t := p.(type) {
+	case Node:
+		return cloneNode(t)
 	case map[string]any:
 		m := make(map[string]any)
 		for k := range t {
@@ -158,64 +262,109 @@ func Clone(p any) any {
 }
 
 func putInKey(p any, key string, val any) (any, error) {
-	dotIndex := strings.IndexRune(key, '.')
-	if dotIndex < 0 { // no nested keys
-		if p == nil {
-			p = createNode(key)
-		}
-		return putInNode(p, key, val)
+	return DefaultLimits.putInPath(p, strings.Split(key, "."), val)
+}
+
+// putInPath puts a value by a key split into segments, so segments may contain dots.
+//
+// Nodes are traversed in a loop, so stack does not grow with key depth: the path is passed down
+// remembering parents, then updated children are put back into their parents.
+func (l Limits) putInPath(p any, path []string, val any) (any, error) {
+	if err := l.checkPath(path); err != nil {
+		return nil, err
 	}
 
+	if err := l.checkPut(p, path, val); err != nil {
+		return nil, err
+	}
+
+	last := len(path) - 1
+	parents := make([]any, last)
+
 	currNode := p
-	currKey := key[:dotIndex]
+	for i, currKey := range path[:last] {
+		if currNode == nil {
+			currNode = createNode(currKey)
+		}
+		parents[i] = currNode
 
-	if currNode == nil {
-		currNode = createNode(currKey)
+		nextNode, err := searchInNode(currNode, currKey)
+		if err != nil && !isNotFound(err) { // a missing child is created, other errors must not be overwritten
+			return nil, err
+		}
+		currNode = nextNode
 	}
 
-	nextKey := key[dotIndex+1:]
-	nextNode, err := searchInNode(currNode, currKey)
-	var invalidPathError *InvalidPathError
-	if errors.As(err, &invalidPathError) {
-		return nil, err
+	if currNode == nil {
+		currNode = createNode(path[last])
 	}
 
-	nextNode, err = putInKey(nextNode, nextKey, val)
+	currNode, err := putInNode(currNode, path[last], val)
 	if err != nil {
 		return nil, err
 	}
 
-	return putInNode(currNode, currKey, nextNode)
+	for i := last - 1; i >= 0; i-- {
+		if currNode, err = putInNode(parents[i], path[i], currNode); err != nil {
+			return nil, err
+		}
+	}
+
+	return currNode, nil
 }
 
-func deleteFromKey(p any, key string) (any, error) {
-	dotIndex := strings.IndexRune(key, '.')
-	if dotIndex < 0 { // no nested keys
-		if p == nil {
-			p = createNode(key)
-		}
-		return deleteFromNode(p, key)
+// deleteFromKey deletes a value by key in a loop the same way putInPath puts it.
+func (l Limits) deleteFromKey(p any, key string, opts DeleteOpts) (any, error) {
+	if err := l.checkKey(key); err != nil {
+		return nil, err
 	}
+	path := strings.Split(key, ".")
+
+	last := len(path) - 1
+	parents := make([]any, last)
 
 	currNode := p
-	currKey := key[:dotIndex]
+	for i, currKey := range path[:last] {
+		parents[i] = currNode
 
-	nextKey := key[dotIndex+1:]
-	nextNode, err := searchInNode(currNode, currKey)
-	if err != nil {
-		return nil, err
+		nextNode, err := searchInNode(currNode, currKey)
+		if err != nil {
+			return nil, err
+		}
+		currNode = nextNode
+	}
+
+	if currNode == nil { // nothing to delete, nodes are not created
+		return nil, &NotFoundError{
+			Path:   path[last],
+			Reason: "no such key in nil node",
+		}
 	}
 
-	nextNode, err = deleteFromKey(nextNode, nextKey)
+	currNode, err := opts.deleteFromNode(currNode, path[last])
 	if err != nil {
 		return nil, err
 	}
 
-	return putInNode(currNode, currKey, nextNode)
+	for i := last - 1; i >= 0; i-- {
+		if opts.PruneEmpty && isEmptyNode(currNode) && isDeletable(parents[i]) {
+			currNode, err = opts.deleteFromNode(parents[i], path[i])
+		} else {
+			currNode, err = putInNode(parents[i], path[i], currNode)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return currNode, nil
 }
 
 func searchInNode(p any, key string) (any, error) {
 	switch t := p.(type) {
+	case Node:
+		return t.Child(key)
 	case map[string]any:
 		if val, ok := t[key]; ok {
 			return val, nil
@@ -254,10 +403,7 @@ func searchInNode(p any, key string) (any, error) {
 			Reason: "no such key in []any",
 		}
 	default:
-		return nil, &InvalidPathError{
-			Path:   key,
-			Reason: "node must be a map[string]any or []any",
-		}
+		return searchInValue(p, key)
 	}
 }
 
@@ -274,7 +420,15 @@ func createNode(key string) any {
 
 func putInNode(p any, key string, val any) (any, error) {
 	switch t := p.(type) {
+	case Node:
+		if err := t.SetChild(key, val); err != nil {
+			return nil, err
+		}
+		return t, nil
 	case map[string]any:
+		if t == nil {
+			t = make(map[string]any)
+		}
 		t[key] = val
 		return t, nil
 	case []any:
@@ -310,15 +464,17 @@ func putInNode(p any, key string, val any) (any, error) {
 		n[i] = val
 		return n, nil
 	default:
-		return nil, &InvalidPathError{
-			Path:   key,
-			Reason: "node must be a map[string]any or []any",
-		}
+		return putInValue(p, key, val)
 	}
 }
 
 func deleteFromNode(p any, key string) (any, error) {
 	switch t := p.(type) {
+	case Node:
+		if err := t.DeleteChild(key); err != nil {
+			return nil, err
+		}
+		return t, nil
 	case map[string]any:
 		if _, ok := t[key]; ok {
 			delete(t, key)
@@ -363,3 +519,47 @@ func deleteFromNode(p any, key string) (any, error) {
 		}
 	}
 }
+
+// deleteFromNode deletes a child of the node, or sets it to nil, if the node is a []any and indexes must be stable.
+func (o DeleteOpts) deleteFromNode(p any, key string) (any, error) {
+	s, ok := p.([]any)
+	if !ok || !o.StableIndexes {
+		return deleteFromNode(p, key)
+	}
+
+	if _, err := searchInNode(s, key); err != nil {
+		return nil, err
+	}
+
+	i, _ := strconv.Atoi(key)
+	if i < 0 {
+		i += len(s)
+	}
+
+	s[i] = nil
+	return s, nil
+}
+
+// isEmptyNode reports whether the node is an empty map[string]any, []any or Node.
+func isEmptyNode(p any) bool {
+	switch t := p.(type) {
+	case Node:
+		return len(t.Children()) == 0
+	case map[string]any:
+		return len(t) == 0
+	case []any:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
+
+// isDeletable reports whether children can be deleted from the node.
+func isDeletable(p any) bool {
+	switch p.(type) {
+	case Node, map[string]any, []any:
+		return true
+	default:
+		return false
+	}
+}
@@ -712,3 +712,244 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteWithOpts(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		key    string
+		opts   mappath.DeleteOpts
+		result any
+		err    any
+	}{
+		"prune empty maps up the chain": {
+			p: map[string]any{
+				"foo": "bar",
+				"a": map[string]any{
+					"b": map[string]any{
+						"c": 1,
+					},
+				},
+			},
+			key:  "a.b.c",
+			opts: mappath.DeleteOpts{PruneEmpty: true},
+			result: map[string]any{
+				"foo": "bar",
+			},
+		},
+		"prune stops at non-empty parent": {
+			p: map[string]any{
+				"a": map[string]any{
+					"b": map[string]any{
+						"c": 1,
+					},
+					"d": 2,
+				},
+			},
+			key:  "a.b.c",
+			opts: mappath.DeleteOpts{PruneEmpty: true},
+			result: map[string]any{
+				"a": map[string]any{
+					"d": 2,
+				},
+			},
+		},
+		"prune empty slices and their elements": {
+			p: map[string]any{
+				"items": []any{
+					"x",
+					[]any{
+						map[string]any{"c": 1},
+					},
+				},
+			},
+			key:  "items.1.0.c",
+			opts: mappath.DeleteOpts{PruneEmpty: true},
+			result: map[string]any{
+				"items": []any{"x"},
+			},
+		},
+		"root is never pruned": {
+			p: map[string]any{
+				"a": map[string]any{"b": 1},
+			},
+			key:    "a.b",
+			opts:   mappath.DeleteOpts{PruneEmpty: true},
+			result: map[string]any{},
+		},
+		"already empty siblings are kept": {
+			p: map[string]any{
+				"a": map[string]any{"b": 1},
+				"e": map[string]any{},
+			},
+			key:  "a.b",
+			opts: mappath.DeleteOpts{PruneEmpty: true},
+			result: map[string]any{
+				"e": map[string]any{},
+			},
+		},
+		"without pruning": {
+			p: map[string]any{
+				"a": map[string]any{"b": 1},
+			},
+			key: "a.b",
+			result: map[string]any{
+				"a": map[string]any{},
+			},
+		},
+		"stable indexes": {
+			p: map[string]any{
+				"items": []any{"a", "b", "c"},
+			},
+			key:  "items.-3",
+			opts: mappath.DeleteOpts{StableIndexes: true},
+			result: map[string]any{
+				"items": []any{nil, "b", "c"},
+			},
+		},
+		"stable indexes, out of range": {
+			p: map[string]any{
+				"items": []any{"a", "b", "c"},
+			},
+			key:    "items.3",
+			opts:   mappath.DeleteOpts{StableIndexes: true},
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"stable indexes with pruning": {
+			p: map[string]any{
+				"items": []any{"a", map[string]any{"b": 1}, "c"},
+			},
+			key:  "items.1.b",
+			opts: mappath.DeleteOpts{StableIndexes: true, PruneEmpty: true},
+			result: map[string]any{
+				"items": []any{"a", nil, "c"},
+			},
+		},
+		"prune, not found": {
+			p: map[string]any{
+				"a": map[string]any{"b": 1},
+			},
+			key:    "a.c",
+			opts:   mappath.DeleteOpts{PruneEmpty: true},
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.DeleteWithOpts(test.p, test.key, test.opts)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		keys   []string
+		result any
+		err    any
+	}{
+		"delete slice elements in any order": {
+			p: map[string]any{
+				"items": []any{"a", "b", "c", "d", "e"},
+			},
+			keys: []string{"items.1", "items.3"},
+			result: map[string]any{
+				"items": []any{"a", "c", "e"},
+			},
+		},
+		"negative indexes are resolved before deletion": {
+			p: map[string]any{
+				"items": []any{"a", "b", "c", "d", "e"},
+			},
+			keys: []string{"items.-1", "items.0", "items.-1"},
+			result: map[string]any{
+				"items": []any{"b", "c", "d"},
+			},
+		},
+		"nested keys are skipped": {
+			p: map[string]any{
+				"foo": "bar",
+				"a":   map[string]any{"b": 1, "c": 2},
+			},
+			keys: []string{"a.b", "a", "a.c"},
+			result: map[string]any{
+				"foo": "bar",
+			},
+		},
+		"missing key, nothing deleted": {
+			p: map[string]any{
+				"items": []any{"a", "b"},
+			},
+			keys:   []string{"items.0", "items.2"},
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"dot key": {
+			p: map[string]any{
+				"items": []any{"a", "b"},
+			},
+			keys:   []string{".", "items.0"},
+			result: nil,
+		},
+		"dot key before missing key, nothing deleted": {
+			p: map[string]any{
+				"items": []any{"a", "b"},
+			},
+			keys:   []string{".", "items.2"},
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"dot key after missing key, nothing deleted": {
+			p: map[string]any{
+				"items": []any{"a", "b"},
+			},
+			keys:   []string{"items.2", "."},
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"invalid key": {
+			p: map[string]any{
+				"items": []any{"a", "b"},
+			},
+			keys:   []string{"items.0", ".items"},
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.DeleteAll(test.p, test.keys...)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
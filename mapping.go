@@ -0,0 +1,85 @@
+package mappath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Mapping reshapes data into a new document by a list of rules.
+type Mapping struct {
+	Rules []MappingRule `json:"rules"`
+}
+
+// MappingRule copies a value from a source path to a target path.
+type MappingRule struct {
+	// From is a source key.
+	From string `json:"from"`
+	// To is a target key, Put rules are used to create missing nodes.
+	To string `json:"to"`
+	// Default is used if there is no value by source key. Nil means no default.
+	Default any `json:"default,omitempty"`
+	// Type converts a value into one of string, int, float or bool, if set.
+	Type string `json:"type,omitempty"`
+	// Required fails mapping if there is no value by source key and no default.
+	Required bool `json:"required,omitempty"`
+}
+
+// ParseMapping decodes a mapping from JSON and checks its rules.
+//
+//	{"rules": [{"from": "usr.login", "to": "metadata.user.login", "required": true}]}
+func ParseMapping(data []byte) (*Mapping, error) {
+	m := &Mapping{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	for i, r := range m.Rules {
+		if len(r.From) == 0 || len(r.To) == 0 {
+			return nil, fmt.Errorf("mappath: mapping rule %v: from and to keys cannot be empty", i)
+		}
+
+		if err := checkType(r.From, r.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Apply produces a new document from the source data. Source data is not modified, mapped values are cloned.
+// Missing source values without defaults are skipped, unless a rule is required.
+func (m *Mapping) Apply(src any) (any, error) {
+	var result any
+	for _, r := range m.Rules {
+		val, err := Get(src, r.From)
+		var notFoundError *NotFoundError
+		switch {
+		case errors.As(err, &notFoundError):
+			if r.Default != nil {
+				val = r.Default
+				break
+			}
+
+			if r.Required {
+				return nil, &NotFoundError{
+					Path:   r.From,
+					Reason: "required value is missing",
+				}
+			}
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		if val, err = convert(r.From, val, r.Type); err != nil {
+			return nil, err
+		}
+
+		if result, err = Put(result, r.To, Clone(val)); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package mappath_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+var testVendorEvent = map[string]any{
+	"usr": map[string]any{
+		"login": "johndoe12",
+		"uid":   "42",
+		"admin": "true",
+	},
+	"ts":     1.7e9,
+	"groups": []any{"employee", "manager"},
+	"note":   nil,
+}
+
+func TestMappingApply(t *testing.T) {
+	tests := map[string]struct {
+		spec   string
+		result any
+		err    error
+	}{
+		"reshape with conversions": {
+			spec: `{"rules": [
+				{"from": "usr.login", "to": "metadata.user.login", "required": true},
+				{"from": "usr.uid", "to": "metadata.user.id", "type": "int"},
+				{"from": "usr.admin", "to": "metadata.user.admin", "type": "bool"},
+				{"from": "ts", "to": "timestamp", "type": "int"},
+				{"from": "groups.0", "to": "metadata.user.roles.0"},
+				{"from": "note", "to": "note"}
+			]}`,
+			result: map[string]any{
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"login": "johndoe12",
+						"id":    42,
+						"admin": true,
+						"roles": []any{"employee"},
+					},
+				},
+				"timestamp": 1700000000,
+				"note":      nil,
+			},
+			err: nil,
+		},
+		"defaults and skipped optional": {
+			spec: `{"rules": [
+				{"from": "usr.email", "to": "email"},
+				{"from": "level", "to": "level", "default": "info"},
+				{"from": "usr.uid", "to": "uid", "type": "float"},
+				{"from": "groups", "to": "roles", "type": "string"}
+			]}`,
+			result: map[string]any{
+				"level": "info",
+				"uid":   42.0,
+				"roles": `["employee","manager"]`,
+			},
+			err: nil,
+		},
+		"required missing, not found": {
+			spec: `{"rules": [
+				{"from": "usr.email", "to": "email", "required": true}
+			]}`,
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"bad conversion, conversion error": {
+			spec: `{"rules": [
+				{"from": "usr.login", "to": "login", "type": "int"}
+			]}`,
+			result: nil,
+			err:    &mappath.ConversionError{},
+		},
+		"conflicting targets, invalid path": {
+			spec: `{"rules": [
+				{"from": "usr.login", "to": "user"},
+				{"from": "usr.uid", "to": "user.id"}
+			]}`,
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := mappath.ParseMapping([]byte(test.spec))
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			val, err := m.Apply(testVendorEvent)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	tests := map[string]struct {
+		spec string
+		err  error
+	}{
+		"unknown type": {
+			spec: `{"rules": [{"from": "a", "to": "b", "type": "date"}]}`,
+			err:  &mappath.ConversionError{},
+		},
+		"empty key": {
+			spec: `{"rules": [{"from": "a"}]}`,
+			err:  errors.New(""),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := mappath.ParseMapping([]byte(test.spec))
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf("unexpected error - want: %T, got: %T", test.err, err)
+			}
+		})
+	}
+}
+
+func TestMappingIntBounds(t *testing.T) {
+	m, err := mappath.ParseMapping([]byte(`{"rules": [{"from": "n", "to": "n", "type": "int"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	tests := map[string]struct {
+		n   any
+		err error
+	}{
+		"min int":         {n: float64(math.MinInt64)},
+		"large int":       {n: float64(1 << 62)},
+		"max int rounded": {n: 9223372036854775807.0, err: &mappath.ConversionError{}},
+		"above max int":   {n: 1e19, err: &mappath.ConversionError{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.Apply(map[string]any{"n": test.n})
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf("unexpected error - want: %T, got: %v", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package mappath
+
+// Node is a custom container, that can be traversed by Get, Put, Delete and Clone.
+// Nodes are checked before built-in types, so a named map or a struct may implement it too.
+//
+// Implementations should return *NotFoundError if there is no such child
+// and *InvalidPathError if provided key cannot address a child of the node.
+type Node interface {
+	// Child returns a child value by key.
+	Child(key string) (any, error)
+	// SetChild adds or replaces a child value by key.
+	SetChild(key string, val any) error
+	// DeleteChild removes a child value by key.
+	DeleteChild(key string) error
+	// Children returns keys of all node children in the order they should be traversed.
+	Children() []string
+}
+
+// NodeCloner is a Node, that can be copied by Clone.
+// Nodes that do not implement it are returned by Clone as is.
+type NodeCloner interface {
+	Node
+	// CloneNode returns a shallow copy of the node, children of the copy are cloned by Clone.
+	// If the copy fails to return or replace a child, the child stays shared with the original node.
+	CloneNode() Node
+}
+
+func cloneNode(n Node) any {
+	c, ok := n.(NodeCloner)
+	if !ok {
+		return n
+	}
+
+	// Clone cannot fail, so children, that cannot be read or replaced, are left as CloneNode copied them.
+	cn := c.CloneNode()
+	for _, k := range cn.Children() {
+		v, err := cn.Child(k)
+		if err != nil {
+			continue
+		}
+		_ = cn.SetChild(k, Clone(v))
+	}
+	return cn
+}
+
+func mergeIntoNode(n Node, val any) (any, bool) {
+	switch t := val.(type) {
+	case map[string]any:
+		for k, v := range t {
+			if err := n.SetChild(k, v); err != nil {
+				return nil, false
+			}
+		}
+		return n, true
+	case Node:
+		for _, k := range t.Children() {
+			v, err := t.Child(k)
+			if err != nil {
+				return nil, false
+			}
+
+			if err := n.SetChild(k, v); err != nil {
+				return nil, false
+			}
+		}
+		return n, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,224 @@
+package mappath_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+// lazyNode decodes its children only when they are requested.
+type lazyNode struct {
+	raw     map[string]json.RawMessage
+	decoded map[string]any
+}
+
+func newLazyNode(data string) *lazyNode {
+	n := &lazyNode{decoded: make(map[string]any)}
+	if err := json.Unmarshal([]byte(data), &n.raw); err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (n *lazyNode) Child(key string) (any, error) {
+	if v, ok := n.decoded[key]; ok {
+		return v, nil
+	}
+
+	raw, ok := n.raw[key]
+	if !ok {
+		return nil, &mappath.NotFoundError{Path: key, Reason: "no such key in lazyNode"}
+	}
+
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("lazyNode: cannot decode %v: %w", key, err)
+	}
+
+	n.decoded[key] = v
+	return v, nil
+}
+
+func (n *lazyNode) SetChild(key string, val any) error {
+	delete(n.raw, key)
+	n.decoded[key] = val
+	return nil
+}
+
+func (n *lazyNode) DeleteChild(key string) error {
+	_, inRaw := n.raw[key]
+	_, inDecoded := n.decoded[key]
+	if !inRaw && !inDecoded {
+		return &mappath.NotFoundError{Path: key, Reason: "no such key in lazyNode"}
+	}
+
+	delete(n.raw, key)
+	delete(n.decoded, key)
+	return nil
+}
+
+func (n *lazyNode) Children() []string {
+	keys := make([]string, 0, len(n.raw)+len(n.decoded))
+	for k := range n.raw {
+		keys = append(keys, k)
+	}
+	for k := range n.decoded {
+		if _, ok := n.raw[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (n *lazyNode) CloneNode() mappath.Node {
+	c := &lazyNode{
+		raw:     make(map[string]json.RawMessage, len(n.raw)),
+		decoded: make(map[string]any, len(n.decoded)),
+	}
+	for k, v := range n.raw {
+		c.raw[k] = v
+	}
+	for k, v := range n.decoded {
+		c.decoded[k] = v
+	}
+	return c
+}
+
+// toMap decodes all node children, so nodes can be compared.
+func (n *lazyNode) toMap() map[string]any {
+	m := make(map[string]any)
+	for _, k := range n.Children() {
+		m[k], _ = n.Child(k)
+	}
+	return m
+}
+
+func TestNodeGet(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		key    string
+		result any
+		err    error
+	}{
+		"from node, nested key, ok value": {
+			p:      newLazyNode(`{"metadata":{"user":{"name":"John Doe"}}}`),
+			key:    "metadata.user.name",
+			result: "John Doe",
+			err:    nil,
+		},
+		"node inside map, ok value": {
+			p: map[string]any{
+				"event": newLazyNode(`{"roles":["employee","manager"]}`),
+			},
+			key:    "event.roles.-1",
+			result: "manager",
+			err:    nil,
+		},
+		"from node, no such key": {
+			p:      newLazyNode(`{"foo":"bar"}`),
+			key:    "fizz",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Get(test.p, test.key)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestNodePutDelete(t *testing.T) {
+	n := newLazyNode(`{"foo":"bar","metadata":{"user":{"name":"John Doe"}}}`)
+
+	p, err := mappath.Put(n, "metadata.user.login", "johndoe12")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if p != n {
+		t.Fatalf("unexpected result - node must be updated in place")
+	}
+
+	p, err = mappath.Delete(p, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	p, err = mappath.Put(p, ".", map[string]any{"level": "info"})
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{
+		"level": "info",
+		"metadata": map[string]any{
+			"user": map[string]any{
+				"name":  "John Doe",
+				"login": "johndoe12",
+			},
+		},
+	}
+
+	if got := p.(*lazyNode).toMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, got)
+	}
+
+	var notFoundError *mappath.NotFoundError
+	if _, err := mappath.Delete(p, "foo"); !errors.As(err, &notFoundError) {
+		t.Errorf("unexpected error - want: %T, got: %T", notFoundError, err)
+	}
+}
+
+func TestNodePutDecodeError(t *testing.T) {
+	n := &lazyNode{
+		raw:     map[string]json.RawMessage{"metadata": json.RawMessage(`{"user":`)},
+		decoded: make(map[string]any),
+	}
+
+	if _, err := mappath.Put(n, "metadata.user", "John Doe"); err == nil {
+		t.Errorf("unexpected error - want: decode error, got: nil")
+	}
+
+	if raw, ok := n.raw["metadata"]; !ok || string(raw) != `{"user":` {
+		t.Errorf("unexpected result - child must not be overwritten, got: %v", n.toMap())
+	}
+}
+
+func TestNodeClone(t *testing.T) {
+	n := newLazyNode(`{"metadata":{"user":{"name":"John Doe"}}}`)
+	c := mappath.Clone(n).(*lazyNode)
+
+	if _, err := mappath.Put(c, "metadata.user.name", "Jane Doe"); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if name, _ := mappath.Get(n, "metadata.user.name"); name != "John Doe" {
+		t.Errorf("unexpected result - origin node changed, got: %v", name)
+	}
+
+	if name, _ := mappath.Get(c, "metadata.user.name"); name != "Jane Doe" {
+		t.Errorf("unexpected result - want: Jane Doe, got: %v", name)
+	}
+}
@@ -0,0 +1,225 @@
+package mappath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// OrderedMap is a Node, that preserves keys insertion order through Put, Delete, Clone and JSON encoding.
+// Zero value is an empty map ready to use.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+var (
+	_ NodeCloner       = (*OrderedMap)(nil)
+	_ json.Marshaler   = (*OrderedMap)(nil)
+	_ json.Unmarshaler = (*OrderedMap)(nil)
+)
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		values: make(map[string]any),
+	}
+}
+
+// Len returns number of keys in the map.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns map keys in insertion order.
+func (m *OrderedMap) Keys() []string {
+	return slices.Clone(m.keys)
+}
+
+// Value returns a value by key and whether the key is present.
+func (m *OrderedMap) Value(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Set adds a key to the end of the map or replaces a value of existing key keeping its position.
+func (m *OrderedMap) Set(key string, val any) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = val
+}
+
+// Remove deletes a key from the map and reports whether the key was present.
+func (m *OrderedMap) Remove(key string) bool {
+	if _, ok := m.values[key]; !ok {
+		return false
+	}
+
+	i := slices.Index(m.keys, key)
+	delete(m.values, key)
+	m.keys = slices.Delete(m.keys, i, i+1)
+	return true
+}
+
+func (m *OrderedMap) Child(key string) (any, error) {
+	if v, ok := m.values[key]; ok {
+		return v, nil
+	}
+
+	return nil, &NotFoundError{
+		Path:   key,
+		Reason: "no such key in OrderedMap",
+	}
+}
+
+func (m *OrderedMap) SetChild(key string, val any) error {
+	m.Set(key, val)
+	return nil
+}
+
+func (m *OrderedMap) DeleteChild(key string) error {
+	if m.Remove(key) {
+		return nil
+	}
+
+	return &NotFoundError{
+		Path:   key,
+		Reason: "no such key in OrderedMap",
+	}
+}
+
+func (m *OrderedMap) Children() []string {
+	return m.Keys()
+}
+
+func (m *OrderedMap) CloneNode() Node {
+	c := &OrderedMap{
+		keys:   slices.Clone(m.keys),
+		values: make(map[string]any, len(m.values)),
+	}
+	for k, v := range m.values {
+		c.values[k] = v
+	}
+	return c
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		val, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, nested objects are decoded as *OrderedMap.
+// JSON null is a no-op, like it is for other types.
+func (m *OrderedMap) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	v, err := DecodeOrdered(data)
+	if err != nil {
+		return err
+	}
+
+	om, ok := v.(*OrderedMap)
+	if !ok {
+		return fmt.Errorf("mappath: cannot unmarshal %T into OrderedMap", v)
+	}
+
+	*m = *om
+	return nil
+}
+
+// DecodeOrdered decodes JSON data like json.Unmarshal into any,
+// except that objects are decoded as *OrderedMap instead of map[string]any.
+func DecodeOrdered(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("mappath: invalid character after top-level value")
+	}
+
+	return v, nil
+}
+
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		m := NewOrderedMap()
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(kt.(string), v)
+		}
+
+		if _, err := dec.Token(); err != nil { // closing brace
+			return nil, err
+		}
+		return m, nil
+	case json.Delim('['):
+		s := []any{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+
+		if _, err := dec.Token(); err != nil { // closing bracket
+			return nil, err
+		}
+		return s, nil
+	default:
+		return tok, nil
+	}
+}
@@ -0,0 +1,125 @@
+package mappath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestOrderedMap(t *testing.T) {
+	tests := map[string]struct {
+		raw    string
+		ops    func(p any) (any, error)
+		result string
+	}{
+		"decode and encode, order preserved": {
+			raw: `{"zeta":1,"alpha":{"b":[{"y":1,"x":2}],"a":null},"mid":"x"}`,
+			ops: func(p any) (any, error) {
+				return p, nil
+			},
+			result: `{"zeta":1,"alpha":{"b":[{"y":1,"x":2}],"a":null},"mid":"x"}`,
+		},
+		"put new key, appended to the end": {
+			raw: `{"zeta":1,"alpha":{"b":2,"a":1}}`,
+			ops: func(p any) (any, error) {
+				return mappath.Put(p, "alpha.0", "new")
+			},
+			result: `{"zeta":1,"alpha":{"b":2,"a":1,"0":"new"}}`,
+		},
+		"put existing key, position kept": {
+			raw: `{"zeta":1,"alpha":2,"mid":3}`,
+			ops: func(p any) (any, error) {
+				return mappath.Put(p, "zeta", "updated")
+			},
+			result: `{"zeta":"updated","alpha":2,"mid":3}`,
+		},
+		"delete and put again, moved to the end": {
+			raw: `{"zeta":1,"alpha":2,"mid":3}`,
+			ops: func(p any) (any, error) {
+				p, err := mappath.Delete(p, "zeta")
+				if err != nil {
+					return nil, err
+				}
+				return mappath.Put(p, "zeta", 1)
+			},
+			result: `{"alpha":2,"mid":3,"zeta":1}`,
+		},
+		"clone, order preserved": {
+			raw: `{"zeta":{"d":1,"c":2},"alpha":[{"y":1,"x":2}]}`,
+			ops: func(p any) (any, error) {
+				return mappath.Clone(p), nil
+			},
+			result: `{"zeta":{"d":1,"c":2},"alpha":[{"y":1,"x":2}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := mappath.DecodeOrdered([]byte(test.raw))
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			p, err = test.ops(p)
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			data, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			if string(data) != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, string(data))
+			}
+		})
+	}
+}
+
+func TestOrderedMapUnmarshal(t *testing.T) {
+	var event struct {
+		Payload *mappath.OrderedMap `json:"payload"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"payload":{"b":1,"a":{"d":true,"c":false}}}`), &event); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if keys := event.Payload.Keys(); len(keys) != 2 || keys[0] != "b" || keys[1] != "a" {
+		t.Errorf("unexpected result - want: [b a], got: %v", keys)
+	}
+
+	if v, err := mappath.Get(event.Payload, "a.c"); err != nil || v != false {
+		t.Errorf("unexpected result - want: false, got: %v, %v", v, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"payload":[1,2]}`), &event); err == nil {
+		t.Errorf("unexpected error - want: error, got: nil")
+	}
+
+	var value struct {
+		Payload mappath.OrderedMap `json:"payload"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"payload":{"b":1}}`), &value); err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"payload":null}`), &value); err != nil {
+		t.Errorf("unexpected error - want: nil, got: %v", err)
+	}
+
+	if keys := value.Payload.Keys(); len(keys) != 1 || keys[0] != "b" {
+		t.Errorf("unexpected result - want: [b], got: %v", keys)
+	}
+}
+
+func TestDecodeOrderedInvalid(t *testing.T) {
+	for _, raw := range []string{`{"a":1`, `{"a":1} {}`, `[1,}`, ``} {
+		if _, err := mappath.DecodeOrdered([]byte(raw)); err == nil {
+			t.Errorf("unexpected error for %q - want: error, got: nil", raw)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package mappath
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type RedactAction int
+
+const (
+	// RedactMask replaces a value with a fixed mask.
+	RedactMask RedactAction = iota
+	// RedactPartial masks all characters of a value except the last ones.
+	RedactPartial
+	// RedactHash replaces a value with hex encoded HMAC-SHA256 of it.
+	RedactHash
+	// RedactDelete deletes a value.
+	RedactDelete
+)
+
+// RedactRule describes how values by a path are redacted.
+type RedactRule struct {
+	// Path is a key or a pattern with the same syntax as Match accepts.
+	Path   string
+	Action RedactAction
+	// Mask replaces a whole value for RedactMask, `***` by default,
+	// and each masked character for RedactPartial, `*` by default.
+	Mask string
+	// Keep is a number of trailing characters that RedactPartial leaves as is.
+	// Values that are not longer than Keep are masked completely.
+	Keep int
+	// Key is a secret key for RedactHash, it cannot be empty.
+	Key []byte
+}
+
+// MaskRule returns a rule, that replaces values by path with the mask.
+func MaskRule(path, mask string) RedactRule {
+	return RedactRule{Path: path, Action: RedactMask, Mask: mask}
+}
+
+// PartialMaskRule returns a rule, that masks all characters of values by path, except last keep ones.
+func PartialMaskRule(path string, keep int) RedactRule {
+	return RedactRule{Path: path, Action: RedactPartial, Keep: keep}
+}
+
+// HashRule returns a rule, that replaces values by path with their HMAC-SHA256 with the key.
+func HashRule(path string, key []byte) RedactRule {
+	return RedactRule{Path: path, Action: RedactHash, Key: key}
+}
+
+// DeleteRule returns a rule, that deletes values by path.
+func DeleteRule(path string) RedactRule {
+	return RedactRule{Path: path, Action: RedactDelete}
+}
+
+// Redact applies rules to passed data in a single pass and returns the updated data.
+// Data is modified in place, like Put does, so clone it first if the original data is needed.
+//
+// If several rules match the same node, the first one is applied. Nodes replaced or deleted by a rule
+// are not traversed further, so a container can be masked or hashed as a whole.
+// Non-string values are masked and hashed by their string or JSON representation.
+func Redact(p any, rules ...RedactRule) (any, error) {
+	patterns := make([][]string, len(rules))
+	for i, r := range rules {
+		if len(r.Path) == 0 || (r.Path[0] == '.' && r.Path != ".") {
+			return nil, &InvalidPathError{
+				Path:   r.Path,
+				Reason: "rule path cannot be empty or start from dot",
+			}
+		}
+
+		if r.Action == RedactHash && len(r.Key) == 0 {
+			return nil, fmt.Errorf("mappath: redact rule %v: hash key cannot be empty", r.Path)
+		}
+
+		if r.Path != "." {
+			patterns[i] = strings.Split(r.Path, ".")
+		}
+	}
+
+	r := &redactor{rules: rules, patterns: patterns}
+	val, _, err := r.redact(nil, p)
+	return val, err
+}
+
+// pathSeg is a segment of a traversed path. Slice elements are also addressable by negative index.
+type pathSeg struct {
+	key string
+	neg string
+}
+
+type redactor struct {
+	rules    []RedactRule
+	patterns [][]string
+}
+
+// redact returns a redacted node and whether it must be deleted from its parent.
+func (r *redactor) redact(path []pathSeg, p any) (any, bool, error) {
+	for i, pattern := range r.patterns {
+		if matchSegments(pattern, path) {
+			return r.rules[i].apply(p)
+		}
+	}
+
+	keys := childrenOf(p)
+	l, indexed := lenOf(p)
+
+	var deleted []string
+	for _, k := range keys {
+		child, err := searchInNode(p, k)
+		if err != nil {
+			return nil, false, err
+		}
+
+		seg := pathSeg{key: k}
+		if indexed {
+			i, _ := strconv.Atoi(k)
+			seg.neg = strconv.Itoa(i - l)
+		}
+
+		child, del, err := r.redact(append(path, seg), child)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if del {
+			deleted = append(deleted, k)
+			continue
+		}
+
+		if p, err = putInNode(p, k, child); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// slice elements are deleted from the end, so indexes of remaining ones stay valid
+	slices.Reverse(deleted)
+	for _, k := range deleted {
+		var err error
+		if p, err = deleteFromNode(p, k); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return p, false, nil
+}
+
+func (r RedactRule) apply(p any) (any, bool, error) {
+	switch r.Action {
+	case RedactMask:
+		if r.Mask == "" {
+			return "***", false, nil
+		}
+		return r.Mask, false, nil
+	case RedactPartial:
+		mask := r.Mask
+		if mask == "" {
+			mask = "*"
+		}
+
+		s := []rune(toString(p))
+		keep := max(0, r.Keep)
+		if keep >= len(s) {
+			keep = 0
+		}
+		return strings.Repeat(mask, len(s)-keep) + string(s[len(s)-keep:]), false, nil
+	case RedactHash:
+		h := hmac.New(sha256.New, r.Key)
+		h.Write([]byte(toString(p)))
+		return hex.EncodeToString(h.Sum(nil)), false, nil
+	case RedactDelete:
+		return nil, true, nil
+	default:
+		return nil, false, fmt.Errorf("mappath: redact rule %v: unknown action %v", r.Path, r.Action)
+	}
+}
+
+// matchSegments reports whether the path matches pattern segments, including `*` and `**` wildcards.
+func matchSegments(pattern []string, path []pathSeg) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+
+	if seg := pattern[0]; seg != "*" && seg != path[0].key && (path[0].neg == "" || seg != path[0].neg) {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
@@ -0,0 +1,146 @@
+package mappath_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func newRedactData() map[string]any {
+	return map[string]any{
+		"message": "user login",
+		"metadata": map[string]any{
+			"user": map[string]any{
+				"email":    "johndoe@gmail.com",
+				"password": "secret",
+				"card":     "4111111111111111",
+			},
+			"password": "secret too",
+		},
+		"tokens": []any{"a", "b", "c", "d"},
+		"ip":     "10.0.0.1",
+	}
+}
+
+func testHMAC(key, value string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestRedact(t *testing.T) {
+	tests := map[string]struct {
+		rules  []mappath.RedactRule
+		result any
+		err    error
+	}{
+		"mask, partial mask, hash and delete": {
+			rules: []mappath.RedactRule{
+				mappath.MaskRule("**.password", ""),
+				mappath.PartialMaskRule("metadata.user.card", 4),
+				mappath.HashRule("metadata.user.email", []byte("key")),
+				mappath.DeleteRule("ip"),
+			},
+			result: map[string]any{
+				"message": "user login",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"email":    testHMAC("key", "johndoe@gmail.com"),
+						"password": "***",
+						"card":     "************1111",
+					},
+					"password": "***",
+				},
+				"tokens": []any{"a", "b", "c", "d"},
+			},
+			err: nil,
+		},
+		"first matching rule wins, container masked as whole": {
+			rules: []mappath.RedactRule{
+				mappath.MaskRule("metadata.user", "[hidden]"),
+				mappath.DeleteRule("metadata.user.password"),
+			},
+			result: map[string]any{
+				"message": "user login",
+				"metadata": map[string]any{
+					"user":     "[hidden]",
+					"password": "secret too",
+				},
+				"tokens": []any{"a", "b", "c", "d"},
+				"ip":     "10.0.0.1",
+			},
+			err: nil,
+		},
+		"delete slice elements, negative index": {
+			rules: []mappath.RedactRule{
+				mappath.DeleteRule("tokens.1"),
+				mappath.DeleteRule("tokens.-1"),
+				mappath.DeleteRule("metadata"),
+			},
+			result: map[string]any{
+				"message": "user login",
+				"tokens":  []any{"a", "c"},
+				"ip":      "10.0.0.1",
+			},
+			err: nil,
+		},
+		"partial mask, short value masked completely": {
+			rules: []mappath.RedactRule{
+				{Path: "tokens.*", Action: mappath.RedactPartial, Keep: 1, Mask: "#"},
+				mappath.DeleteRule("metadata"),
+			},
+			result: map[string]any{
+				"message": "user login",
+				"tokens":  []any{"#", "#", "#", "#"},
+				"ip":      "10.0.0.1",
+			},
+			err: nil,
+		},
+		"root": {
+			rules: []mappath.RedactRule{
+				mappath.DeleteRule("."),
+			},
+			result: nil,
+			err:    nil,
+		},
+		"hash without key, error": {
+			rules: []mappath.RedactRule{
+				mappath.HashRule("ip", nil),
+			},
+			result: nil,
+			err:    errors.New(""),
+		},
+		"invalid path, error": {
+			rules: []mappath.RedactRule{
+				mappath.DeleteRule(".ip"),
+			},
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Redact(newRedactData(), test.rules...)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package mappath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidationError describes a single schema violation. Path is a key of the violating value
+// in the same syntax Get accepts, the root path is a dot.
+type ValidationError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ValidationError) Error() string { return fmt.Sprintf("%v: %v", e.Path, e.Reason) }
+
+// ValidationErrors is returned by Schema.Validate and holds all found violations in traversal order.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// SchemaType is a list of allowed JSON types: null, boolean, object, array, number, string or integer.
+// It is encoded as a single string, if it has one element.
+type SchemaType []string
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = SchemaType{s}
+		return nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(data, &l); err != nil {
+		return fmt.Errorf("mappath: schema type must be a string or an array of strings")
+	}
+	*t = l
+	return nil
+}
+
+// Schema is a subset of JSON Schema draft 2020-12, that supports type, required, properties, items, enum,
+// minimum, maximum, minLength, maxLength, minItems, maxItems, pattern and additionalProperties keywords.
+// Other keywords are ignored.
+//
+// Objects are map[string]any, Node, structs and string-keyed typed maps, arrays are []any and typed slices.
+type Schema struct {
+	Type                 SchemaType         `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+
+	// Boolean makes the schema a boolean one: true accepts any value, false rejects all values,
+	// other fields are ignored. Boolean schemas are decoded from and encoded to true and false.
+	Boolean *bool `json:"-"`
+}
+
+// schemaPatterns caches compiled pattern keywords.
+var schemaPatterns sync.Map // map[string]*regexp.Regexp
+
+// ParseSchema decodes a schema from JSON and checks its types and patterns.
+func ParseSchema(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.Boolean != nil {
+		return json.Marshal(*s.Boolean)
+	}
+
+	type schema Schema // drops methods to avoid recursion
+	return json.Marshal((*schema)(s))
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = Schema{Boolean: &b}
+		return nil
+	}
+
+	type schema Schema
+	return json.Unmarshal(data, (*schema)(s))
+}
+
+// Validate checks passed data against the schema. It returns nil or ValidationErrors with all found violations.
+func (s *Schema) Validate(p any) error {
+	var errs ValidationErrors
+	s.validate(".", p, &errs)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s *Schema) check() error {
+	if s.Pattern != "" {
+		if _, err := compilePattern(s.Pattern); err != nil {
+			return fmt.Errorf("mappath: invalid schema pattern: %w", err)
+		}
+	}
+
+	for _, t := range s.Type {
+		if !slices.Contains(schemaTypes, t) {
+			return fmt.Errorf("mappath: unknown schema type: %v", t)
+		}
+	}
+
+	subs := []*Schema{s.AdditionalProperties, s.Items}
+	for _, sub := range s.Properties {
+		subs = append(subs, sub)
+	}
+
+	for _, sub := range subs {
+		if sub == nil { // null subschemas are skipped by validate
+			continue
+		}
+
+		if err := sub.check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var schemaTypes = []string{"null", "boolean", "object", "array", "number", "string", "integer"}
+
+func (s *Schema) validate(path string, p any, errs *ValidationErrors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &ValidationError{
+			Path:   path,
+			Reason: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if s.Boolean != nil {
+		if !*s.Boolean {
+			fail("value is not allowed")
+		}
+		return
+	}
+
+	typ := typeOf(p)
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool {
+		return t == typ || (t == "number" && typ == "integer")
+	}) {
+		fail("expected %v, got %v", strings.Join(s.Type, " or "), typ)
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(v any) bool { return equal(v, p) }) {
+		fail("value %v is not one of %v", p, s.Enum)
+	}
+
+	switch typ {
+	case "number", "integer":
+		n, _ := toFloat(reflect.Indirect(reflect.ValueOf(p)).Interface())
+		if s.Minimum != nil && n < *s.Minimum {
+			fail("value %v is less than minimum %v", n, *s.Minimum)
+		}
+
+		if s.Maximum != nil && n > *s.Maximum {
+			fail("value %v is greater than maximum %v", n, *s.Maximum)
+		}
+	case "string":
+		str := reflect.Indirect(reflect.ValueOf(p)).String()
+		l := utf8.RuneCountInString(str)
+		if s.MinLength != nil && l < *s.MinLength {
+			fail("length %v is less than minLength %v", l, *s.MinLength)
+		}
+
+		if s.MaxLength != nil && l > *s.MaxLength {
+			fail("length %v is greater than maxLength %v", l, *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+			re, err := compilePattern(s.Pattern)
+			switch {
+			case err != nil:
+				fail("invalid schema pattern: %v", err)
+			case !re.MatchString(str):
+				fail("value %q does not match pattern %q", str, s.Pattern)
+			}
+		}
+	case "array":
+		keys := childrenOf(p)
+		if s.MinItems != nil && len(keys) < *s.MinItems {
+			fail("array length %v is less than minItems %v", len(keys), *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(keys) > *s.MaxItems {
+			fail("array length %v is greater than maxItems %v", len(keys), *s.MaxItems)
+		}
+
+		if s.Items == nil {
+			return
+		}
+
+		for _, k := range keys {
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+			s.Items.validate(joinPath(path, k), child, errs)
+		}
+	case "object":
+		keys := childrenOf(p)
+		for _, r := range s.Required {
+			if !slices.Contains(keys, r) {
+				*errs = append(*errs, &ValidationError{
+					Path:   joinPath(path, r),
+					Reason: "required property is missing",
+				})
+			}
+		}
+
+		for _, k := range keys {
+			sub, ok := s.Properties[k]
+			if !ok {
+				sub = s.AdditionalProperties
+			}
+
+			if sub == nil {
+				continue
+			}
+
+			child, err := searchInNode(p, k)
+			if err != nil {
+				continue
+			}
+			sub.validate(joinPath(path, k), child, errs)
+		}
+	}
+}
+
+// typeOf returns a JSON type name of a value like encoding/json sees it: nil maps and slices are nulls.
+// Whole numbers are integers.
+func typeOf(p any) string {
+	switch t := p.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case Node:
+		return "object"
+	case map[string]any:
+		if t == nil {
+			return "null"
+		}
+		return "object"
+	case []any:
+		if t == nil {
+			return "null"
+		}
+		return "array"
+	}
+
+	if n, ok := toFloat(p); ok {
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+
+	v := reflect.ValueOf(p)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "null"
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return "null"
+		}
+
+		if v.Kind() == reflect.Map {
+			return "object"
+		}
+		return "array"
+	case reflect.Struct:
+		return "object"
+	case reflect.Array:
+		return "array"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return typeOf(v.Interface())
+	default:
+		return v.Kind().String()
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := schemaPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaPatterns.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,190 @@
+package mappath_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+const testEventSchema = `{
+	"type": "object",
+	"required": ["id", "level", "metadata"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"level": {"enum": ["debug", "info", "error"]},
+		"message": {"type": "string", "minLength": 1, "maxLength": 16},
+		"metadata": {
+			"type": "object",
+			"required": ["user"],
+			"properties": {
+				"user": {
+					"type": "object",
+					"properties": {
+						"login": {"type": "string", "pattern": "^[a-z0-9]+$"},
+						"roles": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+					}
+				}
+			},
+			"additionalProperties": false
+		},
+		"score": {"type": ["number", "null"], "maximum": 1}
+	}
+}`
+
+func TestSchemaValidate(t *testing.T) {
+	tests := map[string]struct {
+		data   any
+		errors map[string]string
+	}{
+		"valid document": {
+			data: map[string]any{
+				"id":      42.0,
+				"level":   "info",
+				"message": "hello",
+				"metadata": map[string]any{
+					"user": map[string]any{"login": "johndoe12", "roles": []any{"admin"}},
+				},
+				"score": nil,
+			},
+		},
+		"valid struct": {
+			data: struct {
+				ID       int            `json:"id"`
+				Level    string         `json:"level"`
+				Metadata map[string]any `json:"metadata"`
+				Score    float64        `json:"score"`
+			}{
+				ID:       1,
+				Level:    "debug",
+				Metadata: map[string]any{"user": map[string]any{}},
+				Score:    0.5,
+			},
+		},
+		"violations with paths": {
+			data: map[string]any{
+				"id":      1.5,
+				"level":   "fatal",
+				"message": "",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"login": "John Doe",
+						"roles": []any{"admin", 1, "manager"},
+					},
+					"extra": true,
+				},
+				"score": 2,
+			},
+			errors: map[string]string{
+				"id":                    "expected integer, got number",
+				"level":                 "value fatal is not one of [debug info error]",
+				"message":               "length 0 is less than minLength 1",
+				"metadata.user.login":   `value "John Doe" does not match pattern "^[a-z0-9]+$"`,
+				"metadata.user.roles":   "array length 3 is greater than maxItems 2",
+				"metadata.user.roles.1": "expected string, got integer",
+				"metadata.extra":        "value is not allowed",
+				"score":                 "value 2 is greater than maximum 1",
+			},
+		},
+		"missing required": {
+			data: map[string]any{
+				"id":       1,
+				"metadata": map[string]any{},
+			},
+			errors: map[string]string{
+				"level":         "required property is missing",
+				"metadata.user": "required property is missing",
+			},
+		},
+		"wrong root type": {
+			data: []any{1, 2},
+			errors: map[string]string{
+				".": "expected object, got array",
+			},
+		},
+	}
+
+	schema, err := mappath.ParseSchema([]byte(testEventSchema))
+	if err != nil {
+		t.Fatalf("unexpected schema error: %v", err)
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := schema.Validate(test.data)
+			if test.errors == nil {
+				if err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+				return
+			}
+
+			var errs mappath.ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("unexpected error - want: ValidationErrors, got: %v", err)
+			}
+
+			got := make(map[string]string, len(errs))
+			for _, e := range errs {
+				got[e.Path] = e.Reason
+			}
+
+			if !reflect.DeepEqual(got, test.errors) {
+				t.Errorf("unexpected errors - want: %v, got: %v", test.errors, got)
+			}
+
+			var validationError *mappath.ValidationError
+			if !errors.As(err, &validationError) {
+				t.Errorf("unexpected error - want: ValidationError in chain, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	tests := map[string]struct {
+		spec string
+		err  bool
+	}{
+		"invalid pattern":   {spec: `{"properties": {"a": {"pattern": "("}}}`, err: true},
+		"unknown type":      {spec: `{"items": {"type": "decimal"}}`, err: true},
+		"invalid type":      {spec: `{"type": 1}`, err: true},
+		"null subschemas":   {spec: `{"properties": {"a": null}, "items": null}`},
+		"boolean subschema": {spec: `{"properties": {"a": false}}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schema, err := mappath.ParseSchema([]byte(test.spec))
+			if (err != nil) != test.err {
+				t.Fatalf("unexpected error - want error: %v, got: %v", test.err, err)
+			}
+
+			if err == nil {
+				_ = schema.Validate(map[string]any{"a": 1})
+			}
+		})
+	}
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	schema, err := mappath.ParseSchema([]byte(testEventSchema))
+	if err != nil {
+		t.Fatalf("unexpected schema error: %v", err)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+
+	var got, want any
+	_ = json.Unmarshal(data, &got)
+	_ = json.Unmarshal([]byte(testEventSchema), &want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result - want: %s, got: %s", testEventSchema, data)
+	}
+}
@@ -0,0 +1,193 @@
+package mappath
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Select builds a new data containing only values by specified keys, with their nesting preserved.
+// Keys may be patterns with the same syntax as Match accepts. Selected values are cloned, including structs.
+//
+// Missing keys are skipped, but existing nil values are selected. Parents of selected values are created
+// by kinds of source nodes: slices and arrays produce []any, other containers - map[string]any.
+// Positions of selected slice elements are preserved, so selecting `items.2` produces a slice
+// with two nil elements before the selected one. If nothing is selected, nil is returned.
+func Select(p any, keys ...string) (any, error) {
+	var (
+		result any
+		err    error
+	)
+
+	for _, key := range keys {
+		if key == "." {
+			return deepClone(p), nil
+		}
+
+		for path, val := range matchKey(p, key) {
+			if path, err = resolvePath(p, path); err != nil {
+				return nil, err
+			}
+
+			if result, err = selectInto(result, p, strings.Split(path, "."), deepClone(val)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// selectInto puts a value by path into dst, creating missing nodes of the same kind as source nodes by the path are.
+func selectInto(dst, src any, path []string, val any) (any, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	if dst == nil {
+		if isSlice(src) {
+			dst = []any{}
+		} else {
+			dst = map[string]any{}
+		}
+	}
+
+	srcChild, err := searchInNode(src, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	child, _ := searchInNode(dst, path[0]) // not selected yet
+	if child, err = selectInto(child, srcChild, path[1:], val); err != nil {
+		return nil, err
+	}
+
+	return putInNode(dst, path[0], child)
+}
+
+// Exclude returns a copy of data without values by specified keys.
+// Keys may be patterns with the same syntax as Match accepts, missing keys are skipped.
+// Structs and pointers are copied too, so passed data is never modified.
+//
+// All keys are resolved against the passed data before deletion, so excluding `items.1` and `items.3`
+// removes exactly these elements, regardless of keys order.
+func Exclude(p any, keys ...string) (any, error) {
+	c := deepClone(p)
+
+	var paths []string
+	for _, key := range keys {
+		for path := range matchKey(c, key) {
+			paths = append(paths, path)
+		}
+	}
+
+	return DeleteAll(c, paths...)
+}
+
+// matchKey yields a node by key, or nodes matched by a pattern. Negative indexes of the key are resolved,
+// so yielded paths point to the same nodes in a new data.
+func matchKey(p any, key string) iter.Seq2[string, any] {
+	if isPattern(key) {
+		return Match(p, key)
+	}
+
+	return func(yield func(string, any) bool) {
+		val, err := Get(p, key)
+		if err != nil {
+			return
+		}
+
+		if path, err := resolvePath(p, key); err == nil {
+			yield(path, val)
+		}
+	}
+}
+
+// deletePaths deletes values by resolved paths starting from the greatest ones,
+// so deletion of slice elements does not shift indexes of remaining paths.
+func (l Limits) deletePaths(p any, paths []string) (any, error) {
+	slices.SortFunc(paths, func(a, b string) int { return comparePaths(b, a) })
+	paths = slices.Compact(paths)
+
+	for _, path := range paths {
+		next, err := l.DeleteWithOpts(p, path, DeleteOpts{})
+		var notFoundError *NotFoundError
+		if errors.As(err, &notFoundError) { // parent has already been deleted
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		p = next
+	}
+
+	return p, nil
+}
+
+// comparePaths compares paths segment by segment, numeric segments are compared as numbers.
+func comparePaths(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range min(len(as), len(bs)) {
+		ai, aErr := strconv.Atoi(as[i])
+		bi, bErr := strconv.Atoi(bs[i])
+
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(ai, bi)
+		} else {
+			c = cmp.Compare(as[i], bs[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(as), len(bs))
+}
+
+// resolvePath replaces negative slice indexes in the key with absolute ones.
+func resolvePath(p any, key string) (string, error) {
+	if key == "." {
+		return key, nil
+	}
+
+	path := strings.Split(key, ".")
+	for i, seg := range path {
+		if n, err := strconv.Atoi(seg); err == nil && n < 0 {
+			if l, ok := lenOf(p); ok {
+				path[i] = strconv.Itoa(l + n)
+			}
+		}
+
+		next, err := searchInNode(p, seg)
+		if err != nil {
+			return "", err
+		}
+		p = next
+	}
+
+	return strings.Join(path, "."), nil
+}
+
+// lenOf returns length of a slice or an array.
+func lenOf(p any) (int, bool) {
+	if s, ok := p.([]any); ok {
+		return len(s), true
+	}
+
+	v := reflect.ValueOf(p)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		return v.Len(), true
+	}
+	return 0, false
+}
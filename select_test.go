@@ -0,0 +1,244 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func newSelectData() map[string]any {
+	return map[string]any{
+		"message": "user login",
+		"level":   nil,
+		"metadata": map[string]any{
+			"user": map[string]any{
+				"name":     "John Doe",
+				"password": "secret",
+				"roles":    []any{"employee", "manager", "admin", "guest"},
+			},
+		},
+		"items": []any{
+			map[string]any{"id": 1, "price": 10},
+			map[string]any{"id": 2, "price": 20},
+		},
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := map[string]struct {
+		keys   []string
+		result any
+		err    error
+	}{
+		"nested keys": {
+			keys: []string{"message", "metadata.user.name"},
+			result: map[string]any{
+				"message": "user login",
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"name": "John Doe",
+					},
+				},
+			},
+			err: nil,
+		},
+		"missing skipped, nil kept": {
+			keys: []string{"level", "foo.bar"},
+			result: map[string]any{
+				"level": nil,
+			},
+			err: nil,
+		},
+		"slice element position preserved": {
+			keys: []string{"metadata.user.roles.2"},
+			result: map[string]any{
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"roles": []any{nil, nil, "admin"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"negative index": {
+			keys: []string{"metadata.user.roles.-1"},
+			result: map[string]any{
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"roles": []any{nil, nil, nil, "guest"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"pattern": {
+			keys: []string{"items.*.id"},
+			result: map[string]any{
+				"items": []any{
+					map[string]any{"id": 1},
+					map[string]any{"id": 2},
+				},
+			},
+			err: nil,
+		},
+		"root": {
+			keys:   []string{"message", "."},
+			result: newSelectData(),
+			err:    nil,
+		},
+		"nothing selected": {
+			keys:   []string{"foo"},
+			result: nil,
+			err:    nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := newSelectData()
+			val, err := mappath.Select(data, test.keys...)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+
+			if !reflect.DeepEqual(data, newSelectData()) {
+				t.Errorf("unexpected change of source data: %v", data)
+			}
+		})
+	}
+}
+
+func TestExclude(t *testing.T) {
+	tests := map[string]struct {
+		keys   []string
+		result any
+		err    error
+	}{
+		"nested keys, missing skipped": {
+			keys: []string{"metadata.user.password", "message", "foo.bar"},
+			result: map[string]any{
+				"level": nil,
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"name":  "John Doe",
+						"roles": []any{"employee", "manager", "admin", "guest"},
+					},
+				},
+				"items": []any{
+					map[string]any{"id": 1, "price": 10},
+					map[string]any{"id": 2, "price": 20},
+				},
+			},
+			err: nil,
+		},
+		"several slice elements": {
+			keys: []string{"metadata.user.roles.0", "metadata.user.roles.2", "metadata.user.roles.-1", "items", "message", "level", "metadata.user.name", "metadata.user.password"},
+			result: map[string]any{
+				"metadata": map[string]any{
+					"user": map[string]any{
+						"roles": []any{"manager"},
+					},
+				},
+			},
+			err: nil,
+		},
+		"pattern": {
+			keys: []string{"items.*.price", "metadata", "message", "level"},
+			result: map[string]any{
+				"items": []any{
+					map[string]any{"id": 1},
+					map[string]any{"id": 2},
+				},
+			},
+			err: nil,
+		},
+		"parent and child": {
+			keys:   []string{"metadata.user.name", "metadata", "items", "message", "level"},
+			result: map[string]any{},
+			err:    nil,
+		},
+		"root": {
+			keys:   []string{"."},
+			result: nil,
+			err:    nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := newSelectData()
+			val, err := mappath.Exclude(data, test.keys...)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+
+			if !reflect.DeepEqual(data, newSelectData()) {
+				t.Errorf("unexpected change of source data: %v", data)
+			}
+		})
+	}
+}
+
+func TestSelectMapNumericKeys(t *testing.T) {
+	data := map[string]any{
+		"codes": map[string]any{"404": "not found", "99999999": "huge"},
+	}
+
+	val, err := mappath.Select(data, "codes.404", "codes.99999999")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := map[string]any{
+		"codes": map[string]any{"404": "not found", "99999999": "huge"},
+	}
+	if !reflect.DeepEqual(val, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, val)
+	}
+}
+
+func TestExcludeStruct(t *testing.T) {
+	data := newTestEvent()
+
+	val, err := mappath.Exclude(data, "extra.foo")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	want := newTestEvent()
+	want.Extra = map[string]any{}
+	if !reflect.DeepEqual(val, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, val)
+	}
+
+	if !reflect.DeepEqual(data, newTestEvent()) {
+		t.Errorf("unexpected change of source data: %v", data)
+	}
+
+	if val.(*testEvent).Metadata.User == data.Metadata.User {
+		t.Errorf("unexpected result - pointers must be copied")
+	}
+}
@@ -0,0 +1,442 @@
+package mappath
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// structFields caches resolved field indexes per struct type.
+var structFields sync.Map // reflect.Type -> map[string][]int
+
+// fieldsOf returns a segment to field index mapping for the struct type t.
+// Segments are resolved by json tag name, falling back to the Go field name.
+// Fields of embedded structs and exported pointers to structs without a json name are promoted, unless shadowed,
+// like encoding/json does.
+func fieldsOf(t reflect.Type) map[string][]int {
+	if f, ok := structFields.Load(t); ok {
+		return f.(map[string][]int)
+	}
+
+	fields := collectFields(t, map[reflect.Type]bool{})
+	structFields.Store(t, fields)
+	return fields
+}
+
+// collectFields resolves fields of the struct type t, skipping embedded types already met on the way,
+// so recursive embedding like `type Node struct{ *Node }` is finite.
+func collectFields(t reflect.Type, visited map[reflect.Type]bool) map[string][]int {
+	visited[t] = true
+	defer delete(visited, t)
+
+	fields := make(map[string][]int)
+	var promoted []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			if et := embeddedStruct(f); et != nil {
+				if !visited[et] {
+					promoted = append(promoted, f)
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Index
+	}
+
+	for _, f := range promoted {
+		for name, index := range collectFields(embeddedStruct(f), visited) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = append(slices.Clone(f.Index), index...)
+			}
+		}
+	}
+
+	return fields
+}
+
+// embeddedStruct returns a struct type of an embedded field, whose fields are promoted, or nil.
+// Unexported pointers are not promoted, because they cannot be allocated.
+func embeddedStruct(f reflect.StructField) reflect.Type {
+	switch {
+	case f.Type.Kind() == reflect.Struct:
+		return f.Type
+	case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct && f.IsExported():
+		return f.Type.Elem()
+	default:
+		return nil
+	}
+}
+
+// fieldByIndex returns a nested struct field by index. Nil embedded pointers on the way are allocated,
+// if alloc is set and the struct is addressable, otherwise false is returned.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func searchInValue(p any, key string) (any, error) {
+	v := reflect.ValueOf(p)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: "node is a nil pointer",
+			}
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok := fieldsOf(v.Type())[key]
+		if !ok {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: "no such field in struct",
+			}
+		}
+
+		field, ok := fieldByIndex(v, index, false)
+		if !ok {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: "field is promoted from a nil embedded pointer",
+			}
+		}
+		return field.Interface(), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		val := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !val.IsValid() {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: "no such key in map",
+			}
+		}
+		return val.Interface(), nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, &InvalidPathError{
+				Path:   key,
+				Reason: "target node is a slice, but provided key cannot be converted into int",
+			}
+		}
+
+		if i < 0 {
+			if i += v.Len(); i < 0 {
+				return nil, &InvalidPathError{
+					Path:   key,
+					Reason: "node is a slice, but provided negative index is out of range",
+				}
+			}
+		}
+
+		if i >= v.Len() {
+			return nil, &NotFoundError{
+				Path:   key,
+				Reason: "no such key in slice",
+			}
+		}
+		return v.Index(i).Interface(), nil
+	}
+
+	return nil, &InvalidPathError{
+		Path:   key,
+		Reason: "node must be a map[string]any, []any or struct",
+	}
+}
+
+func putInValue(p any, key string, val any) (any, error) {
+	v := reflect.ValueOf(p)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return nil, &InvalidPathError{
+					Path:   key,
+					Reason: "node is a nil pointer",
+				}
+			}
+			v = reflect.New(v.Type().Elem())
+		}
+
+		if err := putInReflect(v.Elem(), key, val); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		// values stored in an interface are not addressable, so an updated copy is returned
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		if err := putInReflect(c, key, val); err != nil {
+			return nil, err
+		}
+		return c.Interface(), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		if v.IsNil() {
+			v = reflect.MakeMap(v.Type())
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := assignValue(elem, key, val); err != nil {
+			return nil, err
+		}
+		v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		return v.Interface(), nil
+	case reflect.Slice:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, &InvalidPathError{
+				Path:   key,
+				Reason: "node is a slice, but provided key cannot be converted into int",
+			}
+		}
+
+		if i < 0 {
+			if i += v.Len(); i < 0 {
+				return nil, &InvalidPathError{
+					Path:   key,
+					Reason: "node is a slice, but provided negative index is out of range",
+				}
+			}
+		}
+
+		if i >= v.Len() {
+			v = reflect.AppendSlice(v, reflect.MakeSlice(v.Type(), i+1-v.Len(), i+1-v.Len()))
+		}
+
+		if err := assignValue(v.Index(i), key, val); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+
+	return nil, &InvalidPathError{
+		Path:   key,
+		Reason: "node must be a map[string]any, []any or struct",
+	}
+}
+
+// putInReflect sets a field or an element of addressable struct or array.
+func putInReflect(v reflect.Value, key string, val any) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok := fieldsOf(v.Type())[key]
+		if !ok {
+			return &InvalidPathError{
+				Path:   key,
+				Reason: "no such field in struct",
+			}
+		}
+		field, ok := fieldByIndex(v, index, true)
+		if !ok {
+			return &InvalidPathError{
+				Path:   key,
+				Reason: "field is promoted from a nil embedded pointer, that cannot be allocated",
+			}
+		}
+		return assignValue(field, key, val)
+	case reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return &InvalidPathError{
+				Path:   key,
+				Reason: "node is an array, but provided key cannot be converted into int",
+			}
+		}
+
+		if i < 0 {
+			i += v.Len()
+		}
+
+		if i < 0 || i >= v.Len() {
+			return &InvalidPathError{
+				Path:   key,
+				Reason: "node is an array, but provided index is out of range",
+			}
+		}
+		return assignValue(v.Index(i), key, val)
+	default:
+		return &InvalidPathError{
+			Path:   key,
+			Reason: "node must be a map[string]any, []any or struct",
+		}
+	}
+}
+
+// assignValue stores val into dst, converting numbers and dereferencing pointers when needed.
+func assignValue(dst reflect.Value, key string, val any) error {
+	if val == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+	t := dst.Type()
+
+	if v.Type().AssignableTo(t) {
+		dst.Set(v)
+		return nil
+	}
+
+	// pointer field and a value of the pointed type, or vice versa
+	if t.Kind() == reflect.Pointer && v.Type().AssignableTo(t.Elem()) {
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		dst.Set(ptr)
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer && !v.IsNil() && v.Type().Elem().AssignableTo(t) {
+		dst.Set(v.Elem())
+		return nil
+	}
+
+	if isNumberKind(v.Kind()) && isNumberKind(t.Kind()) {
+		c := v.Convert(t)
+		if c.Convert(v.Type()).Equal(v) {
+			dst.Set(c)
+			return nil
+		}
+	}
+
+	return &InvalidPathError{
+		Path:   key,
+		Reason: fmt.Sprintf("value of type %v cannot be assigned to %v", v.Type(), t),
+	}
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// deepClone copies data like Clone does, but structs, pointers, typed maps, slices and arrays are copied too.
+// Unexported struct fields are copied shallowly. A pointer met several times is copied once,
+// so shared and cyclic references are kept.
+func deepClone(p any) any {
+	if p == nil {
+		return nil
+	}
+
+	c := &cloner{pointers: make(map[clonedPointer]reflect.Value)}
+	return c.clone(reflect.ValueOf(p)).Interface()
+}
+
+type clonedPointer struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type cloner struct {
+	pointers map[clonedPointer]reflect.Value
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.New(v.Type()).Elem()
+		out.Set(c.clone(v.Elem()))
+		return out
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+	}
+
+	if n, ok := v.Interface().(Node); ok {
+		if cn := reflect.ValueOf(cloneNode(n)); cn.Type().AssignableTo(v.Type()) {
+			return cn
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		key := clonedPointer{ptr: v.Pointer(), typ: v.Type()}
+		if out, ok := c.pointers[key]; ok {
+			return out
+		}
+
+		out := reflect.New(v.Type().Elem())
+		c.pointers[key] = out
+		out.Elem().Set(c.clone(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			if out.Field(i).CanSet() {
+				out.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Map:
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,312 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+type testUser struct {
+	Name   string         `json:"name"`
+	Email  string         `json:"email,omitempty"`
+	Roles  []string       `json:"roles"`
+	Age    int            `json:"age"`
+	Labels map[string]any `json:"labels"`
+	Secret string         `json:"-"`
+	Nick   string
+}
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testMetadata struct {
+	User    *testUser `json:"user"`
+	Profile testUser  `json:"profile"`
+}
+
+type testEvent struct {
+	testBase
+	Message  string       `json:"message"`
+	Metadata testMetadata `json:"metadata"`
+	Extra    any          `json:"extra"`
+}
+
+type TestTrace struct {
+	TraceID string `json:"trace_id"`
+}
+
+type testTracedEvent struct {
+	*TestTrace
+	Message string `json:"message"`
+}
+
+type TestTree struct {
+	*TestTree
+	Name string `json:"name"`
+}
+
+func newTestEvent() *testEvent {
+	return &testEvent{
+		testBase: testBase{ID: "42"},
+		Message:  "user login",
+		Metadata: testMetadata{
+			User: &testUser{
+				Name:  "John Doe",
+				Roles: []string{"employee", "manager"},
+				Age:   42,
+				Nick:  "jd",
+			},
+		},
+		Extra: map[string]any{
+			"foo": "bar",
+		},
+	}
+}
+
+func TestGetStruct(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		key    string
+		result any
+		err    error
+	}{
+		"by json tag, through pointer, ok value": {
+			p:      newTestEvent(),
+			key:    "metadata.user.name",
+			result: "John Doe",
+			err:    nil,
+		},
+		"by field name, ok value": {
+			p:      newTestEvent(),
+			key:    "metadata.user.Nick",
+			result: "jd",
+			err:    nil,
+		},
+		"typed slice, negative index, ok value": {
+			p:      newTestEvent(),
+			key:    "metadata.user.roles.-1",
+			result: "manager",
+			err:    nil,
+		},
+		"promoted field of embedded struct, ok value": {
+			p:      newTestEvent(),
+			key:    "id",
+			result: "42",
+			err:    nil,
+		},
+		"promoted field of embedded pointer, ok value": {
+			p:      &testTracedEvent{TestTrace: &TestTrace{TraceID: "abc"}},
+			key:    "trace_id",
+			result: "abc",
+			err:    nil,
+		},
+		"promoted field of nil embedded pointer, not found": {
+			p:      &testTracedEvent{},
+			key:    "trace_id",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"recursive embedded pointer, ok value": {
+			p:      TestTree{TestTree: &TestTree{Name: "parent"}, Name: "child"},
+			key:    "name",
+			result: "child",
+			err:    nil,
+		},
+		"struct value, not pointer, ok value": {
+			p:      *newTestEvent(),
+			key:    "message",
+			result: "user login",
+			err:    nil,
+		},
+		"map inside struct, ok value": {
+			p:      newTestEvent(),
+			key:    "extra.foo",
+			result: "bar",
+			err:    nil,
+		},
+		"struct inside map, ok value": {
+			p: map[string]any{
+				"event": newTestEvent(),
+			},
+			key:    "event.metadata.user.age",
+			result: 42,
+			err:    nil,
+		},
+		"ignored field, not found": {
+			p:      newTestEvent(),
+			key:    "metadata.user.Secret",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"field name shadowed by tag, not found": {
+			p:      newTestEvent(),
+			key:    "metadata.user.Name",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"through nil pointer, not found": {
+			p:      &testEvent{},
+			key:    "metadata.user.name",
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Get(test.p, test.key)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestPutStruct(t *testing.T) {
+	tests := map[string]struct {
+		p      any
+		key    string
+		val    any
+		result any
+		err    error
+	}{
+		"update field through pointer, ok result": {
+			p:   newTestEvent(),
+			key: "metadata.user.name",
+			val: "Jane Doe",
+			result: func() any {
+				e := newTestEvent()
+				e.Metadata.User.Name = "Jane Doe"
+				return e
+			}(),
+			err: nil,
+		},
+		"update field of struct value, ok result": {
+			p:   newTestEvent(),
+			key: "metadata.profile.name",
+			val: "Jane Doe",
+			result: func() any {
+				e := newTestEvent()
+				e.Metadata.Profile.Name = "Jane Doe"
+				return e
+			}(),
+			err: nil,
+		},
+		"number conversion, ok result": {
+			p:   newTestEvent(),
+			key: "metadata.user.age",
+			val: float64(43),
+			result: func() any {
+				e := newTestEvent()
+				e.Metadata.User.Age = 43
+				return e
+			}(),
+			err: nil,
+		},
+		"grow typed slice, ok result": {
+			p:   newTestEvent(),
+			key: "metadata.user.roles.3",
+			val: "admin",
+			result: func() any {
+				e := newTestEvent()
+				e.Metadata.User.Roles = []string{"employee", "manager", "", "admin"}
+				return e
+			}(),
+			err: nil,
+		},
+		"allocate nil pointer, ok result": {
+			p:   &testEvent{},
+			key: "metadata.user.name",
+			val: "John Doe",
+			result: &testEvent{
+				Metadata: testMetadata{
+					User: &testUser{Name: "John Doe"},
+				},
+			},
+			err: nil,
+		},
+		"create map in nil typed map, ok result": {
+			p:   &testUser{},
+			key: "labels.env",
+			val: "prod",
+			result: &testUser{
+				Labels: map[string]any{"env": "prod"},
+			},
+			err: nil,
+		},
+		"create nodes in any field, ok result": {
+			p:   &testEvent{},
+			key: "extra.foo.0",
+			val: "bar",
+			result: &testEvent{
+				Extra: map[string]any{
+					"foo": []any{"bar"},
+				},
+			},
+			err: nil,
+		},
+		"allocate nil embedded pointer, ok result": {
+			p:   &testTracedEvent{},
+			key: "trace_id",
+			val: "abc",
+			result: &testTracedEvent{
+				TestTrace: &TestTrace{TraceID: "abc"},
+			},
+			err: nil,
+		},
+		"unknown field, error": {
+			p:      newTestEvent(),
+			key:    "metadata.user.login",
+			val:    "johndoe12",
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+		"incompatible type, error": {
+			p:      newTestEvent(),
+			key:    "metadata.user.age",
+			val:    "42",
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+		"lossy number conversion, error": {
+			p:      newTestEvent(),
+			key:    "metadata.user.age",
+			val:    42.5,
+			result: nil,
+			err:    &mappath.InvalidPathError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Put(test.p, test.key, test.val)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package mappath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type TemplateError struct {
+	Offset int
+	Reason string
+}
+
+func (e *TemplateError) Error() string { return fmt.Sprintf("offset %v: %v", e.Offset, e.Reason) }
+
+// Template is a precompiled string template with placeholders, that are resolved by Get.
+//
+// A placeholder is a key in double braces, optionally followed by filters separated by pipes:
+//
+//	user {{metadata.user.name}} logged in from {{ip|default:"unknown"}}
+//
+// Supported filters are:
+//   - default:"value" - used if there is no value by key or it is nil;
+//   - upper and lower - change case of a value;
+//   - json - encodes a value as JSON instead of its string representation.
+//
+// Filters are applied in order, so `{{name|upper|json}}` renders an upper-cased JSON string.
+// Values are rendered as is for strings and as JSON for containers.
+type Template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	text    string
+	key     string
+	filters []templateFilter
+}
+
+type templateFilter struct {
+	name string
+	arg  string
+}
+
+// CompileTemplate parses a template for repeated use.
+func CompileTemplate(tmpl string) (*Template, error) {
+	t := &Template{}
+	for pos := 0; pos < len(tmpl); {
+		start := strings.Index(tmpl[pos:], "{{")
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{text: tmpl[pos:]})
+			break
+		}
+		start += pos
+
+		if start > pos {
+			t.parts = append(t.parts, templatePart{text: tmpl[pos:start]})
+		}
+
+		part, end, err := parsePlaceholder(tmpl, start+2)
+		if err != nil {
+			return nil, err
+		}
+
+		t.parts = append(t.parts, part)
+		pos = end
+	}
+
+	return t, nil
+}
+
+// Render resolves placeholders with values from data.
+func (t *Template) Render(data any) (string, error) {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.key == "" {
+			b.WriteString(part.text)
+			continue
+		}
+
+		s, err := part.render(data)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+	}
+
+	return b.String(), nil
+}
+
+// Render compiles a template and renders it with values from data.
+func Render(tmpl string, data any) (string, error) {
+	t, err := CompileTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Render(data)
+}
+
+func (p templatePart) render(data any) (string, error) {
+	val, err := Get(data, p.key)
+	var notFoundError *NotFoundError
+	if err != nil && !errors.As(err, &notFoundError) {
+		return "", err
+	}
+
+	if err != nil || val == nil {
+		def, ok := p.defaultValue()
+		switch {
+		case ok:
+			val = def
+		case err != nil:
+			return "", err
+		}
+	}
+
+	// filters are applied in order, each one to the result of the previous one
+	for _, f := range p.filters {
+		switch f.name {
+		case "json":
+			data, err := json.Marshal(val)
+			if err != nil {
+				return "", err
+			}
+			val = string(data)
+		case "upper":
+			val = strings.ToUpper(toString(val))
+		case "lower":
+			val = strings.ToLower(toString(val))
+		}
+	}
+
+	return toString(val), nil
+}
+
+func (p templatePart) defaultValue() (string, bool) {
+	for _, f := range p.filters {
+		if f.name == "default" {
+			return f.arg, true
+		}
+	}
+	return "", false
+}
+
+// parsePlaceholder parses a placeholder body starting at pos and returns position right after closing braces.
+func parsePlaceholder(tmpl string, pos int) (templatePart, int, error) {
+	var (
+		fields []string
+		b      strings.Builder
+		start  = pos
+	)
+
+	for pos < len(tmpl) {
+		switch c := tmpl[pos]; {
+		case c == '"':
+			end := pos + 1
+			for end < len(tmpl) && tmpl[end] != '"' {
+				if tmpl[end] == '\\' {
+					end++
+				}
+				end++
+			}
+
+			if end >= len(tmpl) {
+				return templatePart{}, 0, &TemplateError{Offset: pos, Reason: "unterminated string"}
+			}
+
+			b.WriteString(tmpl[pos : end+1])
+			pos = end + 1
+		case c == '|':
+			fields = append(fields, strings.TrimSpace(b.String()))
+			b.Reset()
+			pos++
+		case strings.HasPrefix(tmpl[pos:], "}}"):
+			fields = append(fields, strings.TrimSpace(b.String()))
+			part, err := newPlaceholder(start, fields)
+			return part, pos + 2, err
+		default:
+			b.WriteByte(c)
+			pos++
+		}
+	}
+
+	return templatePart{}, 0, &TemplateError{Offset: start - 2, Reason: "unclosed placeholder"}
+}
+
+func newPlaceholder(offset int, fields []string) (templatePart, error) {
+	part := templatePart{key: fields[0]}
+	if len(part.key) == 0 || (part.key[0] == '.' && part.key != ".") {
+		return templatePart{}, &TemplateError{Offset: offset, Reason: fmt.Sprintf("invalid key %q", part.key)}
+	}
+
+	for _, field := range fields[1:] {
+		name, arg, hasArg := strings.Cut(field, ":")
+		f := templateFilter{name: strings.TrimSpace(name)}
+
+		switch f.name {
+		case "default":
+			if !hasArg {
+				return templatePart{}, &TemplateError{Offset: offset, Reason: "default filter requires an argument"}
+			}
+
+			arg = strings.TrimSpace(arg)
+			if strings.HasPrefix(arg, `"`) {
+				unquoted, err := strconv.Unquote(arg)
+				if err != nil {
+					return templatePart{}, &TemplateError{Offset: offset, Reason: fmt.Sprintf("invalid default value %v", arg)}
+				}
+				arg = unquoted
+			}
+			f.arg = arg
+		case "upper", "lower", "json":
+			if hasArg {
+				return templatePart{}, &TemplateError{Offset: offset, Reason: fmt.Sprintf("%v filter takes no arguments", f.name)}
+			}
+		default:
+			return templatePart{}, &TemplateError{Offset: offset, Reason: fmt.Sprintf("unknown filter %q", f.name)}
+		}
+
+		part.filters = append(part.filters, f)
+	}
+
+	return part, nil
+}
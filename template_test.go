@@ -0,0 +1,127 @@
+package mappath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+var testTemplateData = map[string]any{
+	"level": "error",
+	"ip":    nil,
+	"metadata": map[string]any{
+		"user": map[string]any{
+			"name":  "John Doe",
+			"roles": []any{"employee", "manager"},
+			"age":   42,
+		},
+	},
+}
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		tmpl   string
+		result string
+		err    error
+	}{
+		"plain text": {
+			tmpl:   "no placeholders",
+			result: "no placeholders",
+			err:    nil,
+		},
+		"placeholders and defaults": {
+			tmpl:   `user {{metadata.user.name}} logged in from {{ip|default:"unknown"}}`,
+			result: "user John Doe logged in from unknown",
+			err:    nil,
+		},
+		"slice index and numbers": {
+			tmpl:   "{{ metadata.user.roles.-1 }}-{{metadata.user.age}}",
+			result: "manager-42",
+			err:    nil,
+		},
+		"filters": {
+			tmpl:   `logs-{{level | upper}}-{{ region | default:"EU" | lower }}`,
+			result: "logs-ERROR-eu",
+			err:    nil,
+		},
+		"containers": {
+			tmpl:   `{{metadata.user.roles}} {{level|json}}`,
+			result: `["employee","manager"] "error"`,
+			err:    nil,
+		},
+		"filters in order": {
+			tmpl:   `{{level|upper|json}} {{metadata.user.roles|json|upper}}`,
+			result: `"ERROR" ["EMPLOYEE","MANAGER"]`,
+			err:    nil,
+		},
+		"braces inside default": {
+			tmpl:   `{{foo|default:"}}"}}`,
+			result: "}}",
+			err:    nil,
+		},
+		"nil without default": {
+			tmpl:   "[{{ip}}]",
+			result: "[]",
+			err:    nil,
+		},
+		"missing without default, not found": {
+			tmpl:   "{{metadata.user.email}}",
+			result: "",
+			err:    &mappath.NotFoundError{},
+		},
+		"unclosed placeholder, template error": {
+			tmpl:   "{{level",
+			result: "",
+			err:    &mappath.TemplateError{},
+		},
+		"unknown filter, template error": {
+			tmpl:   "{{level|title}}",
+			result: "",
+			err:    &mappath.TemplateError{},
+		},
+		"empty key, template error": {
+			tmpl:   "{{ |upper}}",
+			result: "",
+			err:    &mappath.TemplateError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.Render(test.tmpl, testTemplateData)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if val != test.result {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
+
+func TestCompileTemplate(t *testing.T) {
+	tmpl, err := mappath.CompileTemplate("events-{{level}}")
+	if err != nil {
+		t.Fatalf("unexpected error - want: nil, got: %v", err)
+	}
+
+	for level, want := range map[string]string{"info": "events-info", "error": "events-error"} {
+		got, err := tmpl.Render(map[string]any{"level": level})
+		if err != nil {
+			t.Fatalf("unexpected error - want: nil, got: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("unexpected result - want: %v, got: %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package mappath
+
+import (
+	"fmt"
+)
+
+// TransformFunc returns a new value for a node by its path in the source data.
+type TransformFunc func(path string, val any) (any, error)
+
+type TransformOpts struct {
+	// Keys, if set, is called for each key of map[string]any and Node children under the transformed path,
+	// the returned key replaces the original one. The path is the path of the parent node in the source data.
+	// If two keys of a node are replaced by the same key, ConflictError is returned.
+	Keys func(path, key string) (string, error)
+}
+
+// Transform rewrites every leaf value (scalars and empty containers) under specified key in one pass
+// and returns the updated data. Key may be a pattern with the same syntax as Match accepts,
+// in this case all matched nodes are transformed and no matches is not an error.
+func Transform(p any, key string, fn TransformFunc) (any, error) {
+	return TransformWithOpts(p, key, fn, TransformOpts{})
+}
+
+// TransformWithOpts is the same as Transform, but keys may be rewritten too.
+func TransformWithOpts(p any, key string, fn TransformFunc, opts TransformOpts) (any, error) {
+	if !isPattern(key) {
+		val, err := Get(p, key)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err = transform(key, val, fn, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "." {
+			return val, nil
+		}
+		return Put(p, key, val)
+	}
+
+	for _, path := range matchTopmost(p, key) {
+		val, err := Get(p, path)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err = transform(path, val, fn, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if path == "." {
+			return val, nil
+		}
+
+		if p, err = Put(p, path, val); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func transform(path string, p any, fn TransformFunc, opts TransformOpts) (any, error) {
+	keys := childrenOf(p)
+	if len(keys) == 0 {
+		return fn(path, p)
+	}
+
+	_, isMap := p.(map[string]any)
+	_, isNode := p.(Node)
+	renameKeys := opts.Keys != nil && (isMap || isNode)
+
+	// children are transformed before any change of the node, so renamed keys cannot be visited twice
+	newKeys := make([]string, len(keys))
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		child, err := searchInNode(p, k)
+		if err != nil {
+			return nil, err
+		}
+
+		if values[i], err = transform(joinPath(path, k), child, fn, opts); err != nil {
+			return nil, err
+		}
+
+		newKeys[i] = k
+		if renameKeys {
+			if newKeys[i], err = opts.Keys(path, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if renameKeys {
+		seen := make(map[string]string, len(newKeys))
+		for i, k := range newKeys {
+			if prev, ok := seen[k]; ok {
+				return nil, &ConflictError{
+					Path:   joinPath(path, k),
+					Reason: fmt.Sprintf("keys %v and %v are renamed to the same key", prev, keys[i]),
+				}
+			}
+			seen[k] = keys[i]
+		}
+	}
+
+	var err error
+	for i, k := range keys {
+		if newKeys[i] != k {
+			if p, err = deleteFromNode(p, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, k := range newKeys {
+		if p, err = putInNode(p, k, values[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
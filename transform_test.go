@@ -0,0 +1,189 @@
+package mappath_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestTransform(t *testing.T) {
+	upper := func(path string, val any) (any, error) {
+		if s, ok := val.(string); ok {
+			return strings.ToUpper(s), nil
+		}
+		return val, nil
+	}
+
+	tests := map[string]struct {
+		p      any
+		key    string
+		fn     mappath.TransformFunc
+		opts   mappath.TransformOpts
+		result any
+		err    error
+	}{
+		"subtree values": {
+			p: map[string]any{
+				"message": "login",
+				"metadata": map[string]any{
+					"user":  map[string]any{"name": "john", "age": 42},
+					"roles": []any{"employee", "manager"},
+				},
+			},
+			key: "metadata",
+			fn:  upper,
+			result: map[string]any{
+				"message": "login",
+				"metadata": map[string]any{
+					"user":  map[string]any{"name": "JOHN", "age": 42},
+					"roles": []any{"EMPLOYEE", "MANAGER"},
+				},
+			},
+			err: nil,
+		},
+		"single leaf": {
+			p:      map[string]any{"message": "login"},
+			key:    "message",
+			fn:     upper,
+			result: map[string]any{"message": "LOGIN"},
+			err:    nil,
+		},
+		"whole data": {
+			p:      []any{"a", []any{"b"}},
+			key:    ".",
+			fn:     upper,
+			result: []any{"A", []any{"B"}},
+			err:    nil,
+		},
+		"pattern": {
+			p: map[string]any{
+				"items": []any{
+					map[string]any{"name": "foo", "sku": "x"},
+					map[string]any{"sku": "y"},
+				},
+			},
+			key: "items.*.name",
+			fn:  upper,
+			result: map[string]any{
+				"items": []any{
+					map[string]any{"name": "FOO", "sku": "x"},
+					map[string]any{"sku": "y"},
+				},
+			},
+			err: nil,
+		},
+		"nested pattern matches, transformed once": {
+			p: map[string]any{
+				"a": map[string]any{"a": "x"},
+			},
+			key: "**",
+			fn: func(path string, val any) (any, error) {
+				return val.(string) + "!", nil
+			},
+			result: map[string]any{
+				"a": map[string]any{"a": "x!"},
+			},
+			err: nil,
+		},
+		"rewrite keys": {
+			p: map[string]any{
+				"Metadata": map[string]any{
+					"UserName": "john",
+					"Roles":    []any{map[string]any{"Name": "admin"}},
+				},
+			},
+			key: ".",
+			fn: func(path string, val any) (any, error) {
+				return val, nil
+			},
+			opts: mappath.TransformOpts{
+				Keys: func(path, key string) (string, error) {
+					return strings.ToLower(key), nil
+				},
+			},
+			result: map[string]any{
+				"metadata": map[string]any{
+					"username": "john",
+					"roles":    []any{map[string]any{"name": "admin"}},
+				},
+			},
+			err: nil,
+		},
+		"renamed keys collision, conflict": {
+			p: map[string]any{
+				"X": 1,
+				"Y": 2,
+			},
+			key: ".",
+			fn: func(path string, val any) (any, error) {
+				return val, nil
+			},
+			opts: mappath.TransformOpts{
+				Keys: func(path, key string) (string, error) {
+					return "k", nil
+				},
+			},
+			result: nil,
+			err:    &mappath.ConflictError{},
+		},
+		"paths of source data": {
+			p: map[string]any{
+				"a": []any{1, 2},
+			},
+			key: "a",
+			fn: func(path string, val any) (any, error) {
+				return path, nil
+			},
+			result: map[string]any{
+				"a": []any{"a.0", "a.1"},
+			},
+			err: nil,
+		},
+		"no such key, not found": {
+			p:      map[string]any{"message": "login"},
+			key:    "metadata",
+			fn:     upper,
+			result: nil,
+			err:    &mappath.NotFoundError{},
+		},
+		"no pattern matches, ok": {
+			p:      map[string]any{"message": "login"},
+			key:    "metadata.*",
+			fn:     upper,
+			result: map[string]any{"message": "login"},
+			err:    nil,
+		},
+		"fn error": {
+			p:   map[string]any{"message": "login"},
+			key: "message",
+			fn: func(path string, val any) (any, error) {
+				return nil, errors.New("bad value")
+			},
+			result: nil,
+			err:    errors.New(""),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := mappath.TransformWithOpts(test.p, test.key, test.fn, test.opts)
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+			} else {
+				if test.err != nil {
+					t.Errorf("unexpected error - want: nil, got: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(val, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, val)
+			}
+		})
+	}
+}
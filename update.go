@@ -0,0 +1,252 @@
+package mappath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Increment adds a numeric delta to a number by specified key and returns the updated object like Put.
+// An absent value is set to delta, a non-numeric value produces ConversionError.
+//
+// Result keeps the type of the current value: integers stay integers while delta is whole,
+// otherwise they are widened to float64. json.Number is supported too.
+// If an integer result overflows its type, ConversionError is returned.
+func Increment(p any, key string, delta any) (any, error) {
+	return DefaultLimits.Increment(p, key, delta)
+}
+
+// Increment is the same as package Increment, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Increment(p any, key string, delta any) (any, error) {
+	curr, err := getForUpdate(p, key)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := addNumber(key, curr, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Put(p, key, val)
+}
+
+// Toggle inverts a boolean by specified key. An absent value is set to true, a non-boolean value produces ConversionError.
+func Toggle(p any, key string) (any, error) {
+	return DefaultLimits.Toggle(p, key)
+}
+
+// Toggle is the same as package Toggle, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Toggle(p any, key string) (any, error) {
+	curr, err := getForUpdate(p, key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := curr.(type) {
+	case nil:
+		return l.Put(p, key, true)
+	case bool:
+		return l.Put(p, key, !t)
+	default:
+		return nil, &ConversionError{
+			Path:   key,
+			Reason: fmt.Sprintf("value %v of type %T is not a bool", curr, curr),
+		}
+	}
+}
+
+// AppendTo appends values to the end of a slice by specified key and returns the updated object like Put.
+//
+// An absent slice is created, a value, that is not a slice, produces ConversionError.
+// Prepend, AddToSet and RemoveFromSlice handle absent values and other types the same way.
+func AppendTo(p any, key string, vals ...any) (any, error) {
+	return DefaultLimits.AppendTo(p, key, vals...)
+}
+
+// AppendTo is the same as package AppendTo, but passed limits are applied instead of DefaultLimits.
+func (l Limits) AppendTo(p any, key string, vals ...any) (any, error) {
+	return l.updateSlice(p, key, func(elems []any) []any {
+		return append(elems, vals...)
+	})
+}
+
+// Prepend inserts values at the start of a slice by specified key.
+func Prepend(p any, key string, vals ...any) (any, error) {
+	return DefaultLimits.Prepend(p, key, vals...)
+}
+
+// Prepend is the same as package Prepend, but passed limits are applied instead of DefaultLimits.
+func (l Limits) Prepend(p any, key string, vals ...any) (any, error) {
+	return l.updateSlice(p, key, func(elems []any) []any {
+		return append(slices.Clone(vals), elems...)
+	})
+}
+
+// AddToSet appends values to a slice by specified key, skipping ones that are already in the slice.
+// Numbers are compared by value, other values - deeply.
+func AddToSet(p any, key string, vals ...any) (any, error) {
+	return DefaultLimits.AddToSet(p, key, vals...)
+}
+
+// AddToSet is the same as package AddToSet, but passed limits are applied instead of DefaultLimits.
+func (l Limits) AddToSet(p any, key string, vals ...any) (any, error) {
+	return l.updateSlice(p, key, func(elems []any) []any {
+		for _, v := range vals {
+			if !slices.ContainsFunc(elems, func(e any) bool { return equal(e, v) }) {
+				elems = append(elems, v)
+			}
+		}
+		return elems
+	})
+}
+
+// RemoveFromSlice removes all elements equal to the value from a slice by specified key.
+func RemoveFromSlice(p any, key string, val any) (any, error) {
+	return DefaultLimits.RemoveFromSlice(p, key, val)
+}
+
+// RemoveFromSlice is the same as package RemoveFromSlice, but passed limits are applied instead of DefaultLimits.
+func (l Limits) RemoveFromSlice(p any, key string, val any) (any, error) {
+	return l.updateSlice(p, key, func(elems []any) []any {
+		return slices.DeleteFunc(elems, func(e any) bool { return equal(e, val) })
+	})
+}
+
+// getForUpdate returns the current value by key, or nil, if there is no such value.
+func getForUpdate(p any, key string) (any, error) {
+	curr, err := Get(p, key)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	return curr, nil
+}
+
+// updateSlice applies fn to elements of []any or a typed slice by key and puts the result back.
+// Elements of typed slices are converted back to the element type.
+func (l Limits) updateSlice(p any, key string, fn func(elems []any) []any) (any, error) {
+	curr, err := getForUpdate(p, key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := curr.(type) {
+	case nil:
+		return l.Put(p, key, fn([]any{}))
+	case []any:
+		return l.Put(p, key, fn(t))
+	}
+
+	v := reflect.ValueOf(curr)
+	if v.Kind() != reflect.Slice {
+		return nil, &ConversionError{
+			Path:   key,
+			Reason: fmt.Sprintf("value %v of type %T is not a slice", curr, curr),
+		}
+	}
+
+	elems := make([]any, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+
+	elems = fn(elems)
+	s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+	for i, e := range elems {
+		if err := assignValue(s.Index(i), joinPath(key, strconv.Itoa(i)), e); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.Put(p, key, s.Interface())
+}
+
+func addNumber(key string, curr, delta any) (any, error) {
+	d, ok := toFloat(delta)
+	if !ok {
+		return nil, &ConversionError{
+			Path:   key,
+			Reason: fmt.Sprintf("delta %v of type %T is not a number", delta, delta),
+		}
+	}
+
+	if curr == nil {
+		return delta, nil
+	}
+
+	c, ok := toFloat(curr)
+	if !ok {
+		return nil, &ConversionError{
+			Path:   key,
+			Reason: fmt.Sprintf("value %v of type %T is not a number", curr, curr),
+		}
+	}
+
+	if _, ok := curr.(json.Number); ok {
+		return json.Number(strconv.FormatFloat(c+d, 'f', -1, 64)), nil
+	}
+
+	cv := reflect.ValueOf(curr)
+	switch {
+	case (cv.CanInt() || cv.CanUint()) && d == math.Trunc(d):
+		return addInt(key, cv, delta, d)
+	case cv.CanFloat():
+		return reflect.ValueOf(c + d).Convert(cv.Type()).Interface(), nil
+	default:
+		return c + d, nil
+	}
+}
+
+// addInt adds a whole delta to an integer, keeping its type. Overflow produces ConversionError.
+func addInt(key string, cv reflect.Value, delta any, d float64) (any, error) {
+	overflow := &ConversionError{
+		Path:   key,
+		Reason: fmt.Sprintf("sum of %v and %v overflows %v", cv.Interface(), delta, cv.Type()),
+	}
+
+	var n int64
+	switch dv := reflect.ValueOf(delta); {
+	case dv.CanInt():
+		n = dv.Int()
+	case dv.CanUint():
+		if dv.Uint() > math.MaxInt64 {
+			return nil, overflow
+		}
+		n = int64(dv.Uint())
+	default:
+		if d < math.MinInt64 || d >= math.MaxInt64 {
+			return nil, overflow
+		}
+		n = int64(d)
+	}
+
+	res := reflect.New(cv.Type()).Elem()
+	if cv.CanInt() {
+		a := cv.Int()
+		sum := a + n
+		if (n > 0 && sum < a) || (n < 0 && sum > a) || cv.OverflowInt(sum) {
+			return nil, overflow
+		}
+		res.SetInt(sum)
+		return res.Interface(), nil
+	}
+
+	a, abs := cv.Uint(), uint64(n)
+	if n < 0 {
+		abs = uint64(-n)
+	}
+
+	sum := a + abs
+	if n < 0 {
+		sum = a - abs
+	}
+
+	if (n >= 0 && sum < a) || (n < 0 && abs > a) || cv.OverflowUint(sum) {
+		return nil, overflow
+	}
+	res.SetUint(sum)
+	return res.Interface(), nil
+}
@@ -0,0 +1,172 @@
+package mappath_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestUpdate(t *testing.T) {
+	newData := func() any {
+		return map[string]any{
+			"hits":    2.0,
+			"retries": 3,
+			"size":    json.Number("10"),
+			"enabled": true,
+			"tags":    []any{"a", "b", "a"},
+			"user":    &testUser{Name: "John", Roles: []string{"admin"}, Age: 30},
+			"name":    "foo",
+			"small":   int8(127),
+			"count":   uint8(3),
+		}
+	}
+
+	tests := map[string]struct {
+		update func(p any) (any, error)
+		key    string
+		result any
+		err    error
+	}{
+		"increment float": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "hits", 1) },
+			key:    "hits",
+			result: 3.0,
+		},
+		"increment int": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "retries", -1) },
+			key:    "retries",
+			result: 2,
+		},
+		"increment int by fraction": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "retries", 0.5) },
+			key:    "retries",
+			result: 3.5,
+		},
+		"increment json number": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "size", 5) },
+			key:    "size",
+			result: json.Number("15"),
+		},
+		"increment struct field": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "user.age", 1.0) },
+			key:    "user.age",
+			result: 31,
+		},
+		"increment absent": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "stats.errors", 1) },
+			key:    "stats.errors",
+			result: 1,
+		},
+		"increment uint": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "count", -3) },
+			key:    "count",
+			result: uint8(0),
+		},
+		"increment int overflow, conversion error": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "small", 1) },
+			err:    &mappath.ConversionError{},
+		},
+		"increment uint below zero, conversion error": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "count", -5) },
+			err:    &mappath.ConversionError{},
+		},
+		"increment uint overflow, conversion error": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "count", 253.0) },
+			err:    &mappath.ConversionError{},
+		},
+		"increment string, conversion error": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "name", 1) },
+			err:    &mappath.ConversionError{},
+		},
+		"increment by string, conversion error": {
+			update: func(p any) (any, error) { return mappath.Increment(p, "hits", "1") },
+			err:    &mappath.ConversionError{},
+		},
+		"toggle": {
+			update: func(p any) (any, error) { return mappath.Toggle(p, "enabled") },
+			key:    "enabled",
+			result: false,
+		},
+		"toggle absent": {
+			update: func(p any) (any, error) { return mappath.Toggle(p, "debug") },
+			key:    "debug",
+			result: true,
+		},
+		"toggle string, conversion error": {
+			update: func(p any) (any, error) { return mappath.Toggle(p, "name") },
+			err:    &mappath.ConversionError{},
+		},
+		"append": {
+			update: func(p any) (any, error) { return mappath.AppendTo(p, "tags", "c", "d") },
+			key:    "tags",
+			result: []any{"a", "b", "a", "c", "d"},
+		},
+		"append absent": {
+			update: func(p any) (any, error) { return mappath.AppendTo(p, "meta.tags", "c") },
+			key:    "meta.tags",
+			result: []any{"c"},
+		},
+		"append to typed slice": {
+			update: func(p any) (any, error) { return mappath.AppendTo(p, "user.roles", "manager") },
+			key:    "user.roles",
+			result: []string{"admin", "manager"},
+		},
+		"append wrong type to typed slice, invalid path": {
+			update: func(p any) (any, error) { return mappath.AppendTo(p, "user.roles", 1) },
+			err:    &mappath.InvalidPathError{},
+		},
+		"append to string, conversion error": {
+			update: func(p any) (any, error) { return mappath.AppendTo(p, "name", "c") },
+			err:    &mappath.ConversionError{},
+		},
+		"prepend": {
+			update: func(p any) (any, error) { return mappath.Prepend(p, "tags", "c", "d") },
+			key:    "tags",
+			result: []any{"c", "d", "a", "b", "a"},
+		},
+		"add to set": {
+			update: func(p any) (any, error) { return mappath.AddToSet(p, "tags", "b", "c", "c") },
+			key:    "tags",
+			result: []any{"a", "b", "a", "c"},
+		},
+		"remove from slice": {
+			update: func(p any) (any, error) { return mappath.RemoveFromSlice(p, "tags", "a") },
+			key:    "tags",
+			result: []any{"b"},
+		},
+		"remove from typed slice": {
+			update: func(p any) (any, error) { return mappath.RemoveFromSlice(p, "user.roles", "admin") },
+			key:    "user.roles",
+			result: []string{},
+		},
+		"remove from absent": {
+			update: func(p any) (any, error) { return mappath.RemoveFromSlice(p, "meta.tags", "a") },
+			key:    "meta.tags",
+			result: []any{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := test.update(newData())
+
+			if err != nil {
+				if !(reflect.TypeOf(err) == reflect.TypeOf(test.err)) {
+					t.Errorf("unexpected error - want: %v, got: %v", reflect.TypeOf(test.err).String(), reflect.TypeOf(err).String())
+				}
+				return
+			}
+
+			if test.err != nil {
+				t.Fatalf("unexpected error - want: %v, got: nil", reflect.TypeOf(test.err).String())
+			}
+
+			result, _ := mappath.Get(data, test.key)
+			if !reflect.DeepEqual(result, test.result) {
+				t.Errorf("unexpected result - want: %v (%T), got: %v (%T)", test.result, test.result, result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package mappath
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// SkipChildren is used as a return value from WalkFunc to indicate that children of the node are to be skipped.
+// It is not returned as an error by any function.
+var SkipChildren = errors.New("skip children")
+
+// WalkFunc is called by Walk for each visited node with its path in the same syntax Get accepts.
+// The root node path is a dot.
+//
+// If the function returns SkipChildren, children of the node are not visited.
+// Any other non-nil error stops the walk and is returned by Walk.
+type WalkFunc func(path string, val any) error
+
+type WalkOpts struct {
+	// PostOrder visits children before their parent.
+	// In this mode SkipChildren has no effect.
+	PostOrder bool
+	// LeavesOnly visits only nodes without children - scalars and empty containers.
+	LeavesOnly bool
+}
+
+// Walk traverses passed map[string]any, []any, Node or struct in pre-order and calls fn for each node including the root.
+// Map keys are visited in sorted order, Node children - in the order returned by Children.
+//
+// Data must not be modified by fn during the walk.
+func Walk(p any, fn WalkFunc) error {
+	return WalkWithOpts(p, fn, WalkOpts{})
+}
+
+// WalkWithOpts is the same as Walk, but traversal is configured by provided options.
+func WalkWithOpts(p any, fn WalkFunc, opts WalkOpts) error {
+	err := walk(".", p, fn, opts)
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	return err
+}
+
+func walk(path string, p any, fn WalkFunc, opts WalkOpts) error {
+	keys := childrenOf(p)
+	if len(keys) == 0 {
+		return skipChildren(fn(path, p))
+	}
+
+	if !opts.PostOrder && !opts.LeavesOnly {
+		if err := fn(path, p); err != nil {
+			return skipChildren(err)
+		}
+	}
+
+	for _, k := range keys {
+		child, err := searchInNode(p, k)
+		if err != nil {
+			return err
+		}
+
+		if err := walk(joinPath(path, k), child, fn, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.PostOrder && !opts.LeavesOnly {
+		return skipChildren(fn(path, p))
+	}
+
+	return nil
+}
+
+func skipChildren(err error) error {
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	return err
+}
+
+// joinPath appends a key to a parent path, where the root path is a dot.
+func joinPath(path, key string) string {
+	if path == "." {
+		return key
+	}
+	return path + "." + key
+}
+
+// childrenOf returns keys of node children in traversal order, or nil, if node has no children.
+func childrenOf(p any) []string {
+	switch t := p.(type) {
+	case Node:
+		return t.Children()
+	case map[string]any:
+		return slices.Sorted(maps.Keys(t))
+	case []any:
+		return indexKeys(len(t))
+	}
+
+	v := reflect.ValueOf(p)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := fieldsOf(v.Type())
+		keys := slices.Collect(maps.Keys(fields))
+		keys = slices.DeleteFunc(keys, func(k string) bool { // fields of nil embedded pointers
+			_, ok := fieldByIndex(v, fields[k], false)
+			return !ok
+		})
+		slices.SortFunc(keys, func(a, b string) int { // declaration order
+			return slices.Compare(fields[a], fields[b])
+		})
+		return keys
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		slices.Sort(keys)
+		return keys
+	case reflect.Slice, reflect.Array:
+		return indexKeys(v.Len())
+	default:
+		return nil
+	}
+}
+
+func indexKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
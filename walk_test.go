@@ -0,0 +1,168 @@
+package mappath_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/mappath"
+)
+
+func TestWalk(t *testing.T) {
+	data := map[string]any{
+		"message": "user login",
+		"metadata": map[string]any{
+			"user": map[string]any{
+				"name":  "John Doe",
+				"roles": []any{"employee", "manager"},
+			},
+			"tags": map[string]any{},
+		},
+	}
+
+	tests := map[string]struct {
+		p      any
+		opts   mappath.WalkOpts
+		skip   string
+		result []string
+	}{
+		"pre-order": {
+			p:    data,
+			opts: mappath.WalkOpts{},
+			result: []string{
+				".",
+				"message",
+				"metadata",
+				"metadata.tags",
+				"metadata.user",
+				"metadata.user.name",
+				"metadata.user.roles",
+				"metadata.user.roles.0",
+				"metadata.user.roles.1",
+			},
+		},
+		"post-order": {
+			p:    data,
+			opts: mappath.WalkOpts{PostOrder: true},
+			result: []string{
+				"message",
+				"metadata.tags",
+				"metadata.user.name",
+				"metadata.user.roles.0",
+				"metadata.user.roles.1",
+				"metadata.user.roles",
+				"metadata.user",
+				"metadata",
+				".",
+			},
+		},
+		"leaves only": {
+			p:    data,
+			opts: mappath.WalkOpts{LeavesOnly: true},
+			result: []string{
+				"message",
+				"metadata.tags",
+				"metadata.user.name",
+				"metadata.user.roles.0",
+				"metadata.user.roles.1",
+			},
+		},
+		"skip children": {
+			p:    data,
+			opts: mappath.WalkOpts{},
+			skip: "metadata.user",
+			result: []string{
+				".",
+				"message",
+				"metadata",
+				"metadata.tags",
+				"metadata.user",
+			},
+		},
+		"skip children of root": {
+			p:      data,
+			opts:   mappath.WalkOpts{},
+			skip:   ".",
+			result: []string{"."},
+		},
+		"scalar root": {
+			p:      "foo",
+			opts:   mappath.WalkOpts{LeavesOnly: true},
+			result: []string{"."},
+		},
+		"struct, declaration order": {
+			p:    newTestEvent(),
+			opts: mappath.WalkOpts{LeavesOnly: true},
+			result: []string{
+				"id",
+				"message",
+				"metadata.user.name",
+				"metadata.user.email",
+				"metadata.user.roles.0",
+				"metadata.user.roles.1",
+				"metadata.user.age",
+				"metadata.user.labels",
+				"metadata.user.Nick",
+				"metadata.profile.name",
+				"metadata.profile.email",
+				"metadata.profile.roles",
+				"metadata.profile.age",
+				"metadata.profile.labels",
+				"metadata.profile.Nick",
+				"extra.foo",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			err := mappath.WalkWithOpts(test.p, func(path string, val any) error {
+				paths = append(paths, path)
+
+				got, err := mappath.Get(test.p, path)
+				if err != nil {
+					t.Errorf("unexpected error on %v - want: nil, got: %v", path, err)
+				}
+
+				if !reflect.DeepEqual(got, val) {
+					t.Errorf("unexpected value on %v - want: %v, got: %v", path, got, val)
+				}
+
+				if path == test.skip {
+					return mappath.SkipChildren
+				}
+				return nil
+			}, test.opts)
+
+			if err != nil {
+				t.Fatalf("unexpected error - want: nil, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(paths, test.result) {
+				t.Errorf("unexpected result - want: %v, got: %v", test.result, paths)
+			}
+		})
+	}
+}
+
+func TestWalkStop(t *testing.T) {
+	errStop := errors.New("stop")
+
+	var paths []string
+	err := mappath.Walk([]any{1, 2, 3}, func(path string, val any) error {
+		paths = append(paths, path)
+		if path == "1" {
+			return errStop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("unexpected error - want: %v, got: %v", errStop, err)
+	}
+
+	if want := []string{".", "0", "1"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("unexpected result - want: %v, got: %v", want, paths)
+	}
+}